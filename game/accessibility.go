@@ -0,0 +1,93 @@
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	normalTPS                = 60
+	slowTPS                  = 45 // the whole game runs at three quarters speed, physics included
+	toggleButtonWidth        = 200
+	toggleButtonHeight       = 26
+	toggleButtonSpacing      = 6
+	highContrastOutlineBoost = 2
+)
+
+var (
+	unvisitedColorblindTint = newTint(1, .7, .35) // orange and blue stay apart for every common kind of colorblindness
+	visitedColorblindTint   = newTint(.45, .7, 1)
+)
+
+func newTint(r, g, b float32) ebiten.ColorScale {
+	var tint ebiten.ColorScale
+	tint.Scale(r, g, b, 1)
+	return tint
+}
+
+// applySettings sets up everything that isn't checked every frame
+func applySettings() {
+	if settings.SlowSpeed {
+		ebiten.SetTPS(slowTPS)
+	} else {
+		ebiten.SetTPS(normalTPS)
+	}
+}
+
+// buildingTint tells visited and unvisited rooftops apart by more than their artwork when the colorblind setting is on
+func buildingTint(visited bool) ebiten.ColorScale {
+	switch {
+	case !settings.Colorblind:
+		return ebiten.ColorScale{}
+	case visited:
+		return visitedColorblindTint
+	default:
+		return unvisitedColorblindTint
+	}
+}
+
+func (g *Game) initAccessibilityButtons() {
+	g.accessibilityButtons = newAccessibilityToggles(toggleButtonWidth, toggleButtonHeight)
+	g.layoutAccessibilityButtons()
+}
+
+// newAccessibilityToggles makes a set of toggles for the accessibility settings, they need to be laid out after
+func newAccessibilityToggles(width, height float64) []*Button {
+	return []*Button{
+		NewToggleButton(0, 0, width, height, "Reduced motion", &settings.ReducedMotion, saveSettings),
+		NewToggleButton(0, 0, width, height, "High contrast", &settings.HighContrast, saveSettings),
+		NewToggleButton(0, 0, width, height, "Colorblind colors", &settings.Colorblind, saveSettings),
+		NewToggleButton(0, 0, width, height, "Slower game", &settings.SlowSpeed, saveSettings),
+	}
+}
+
+// layoutAccessibilityButtons stacks the toggles in the bottom left corner of the title screen
+func (g *Game) layoutAccessibilityButtons() {
+	bottom := screenHeight - hudMargin - toggleButtonHeight/2
+	for i, b := range g.accessibilityButtons {
+		row := len(g.accessibilityButtons) - 1 - i
+		b.SetCenter(hudMargin+toggleButtonWidth/2, float64(bottom-row*(toggleButtonHeight+toggleButtonSpacing)))
+	}
+}
+
+func (g *Game) updateAccessibilityButtons() {
+	for _, b := range g.accessibilityButtons {
+		b.Update()
+	}
+}
+
+func (g *Game) drawAccessibilityButtons(screen *ebiten.Image) {
+	for _, b := range g.accessibilityButtons {
+		b.Draw(screen)
+	}
+}
+
+// tapAccessibilityButton is for the mobile click handler, it reports whether a toggle was tapped
+func (g *Game) tapAccessibilityButton(x, y int) bool {
+	for _, b := range g.accessibilityButtons {
+		if b.Overlaps(x, y) {
+			b.press()
+			return true
+		}
+	}
+	return false
+}
@@ -3,6 +3,7 @@ package game
 import (
 	"bytes"
 	"log"
+	"math"
 
 	"github.com/hajimehoshi/ebiten/v2/audio"
 	"github.com/hajimehoshi/ebiten/v2/audio/mp3"
@@ -15,11 +16,15 @@ var (
 	isMuted      bool
 )
 
-const sampleRate = 44100 // Standard audio sample rate
+const (
+	sampleRate = 44100 // Standard audio sample rate
+	volumeStep = .25
+)
 
 // InitializeAudio initializes the audio context and player with the provided MP3 data
 func InitializeAudio(bgmData []byte) {
 	audioContext = audio.NewContext(sampleRate)
+	sounds = NewSoundEffects(audioContext)
 
 	// Decode MP3 from the provided byte slice (bgmData)
 	stream, err := mp3.DecodeWithSampleRate(sampleRate, bytes.NewReader(bgmData))
@@ -33,19 +38,35 @@ func InitializeAudio(bgmData []byte) {
 		log.Fatal(err)
 	}
 
-	// Play the audio
+	// Play the audio at the volume the player left it at last time
+	isMuted = settings.Muted
+	applyVolume()
 	player.Play()
 }
 
 // ToggleMute toggles the mute state of the audio player
 func ToggleMute() {
+	isMuted = !isMuted
+	settings.Muted = isMuted
+	saveSettings()
+	applyVolume()
+}
+
+// CycleVolume turns the music up a step, going back to silent after the loudest
+func CycleVolume() {
+	steps := math.Round(settings.Volume / volumeStep)
+	settings.Volume = math.Mod(steps+1, 1/volumeStep+1) * volumeStep
+	saveSettings()
+	applyVolume()
+}
+
+func applyVolume() {
+	if player == nil { // the settings can change before InitializeAudio has run
+		return
+	}
 	if isMuted {
-		// Unmute audio
-		player.SetVolume(1)
-		isMuted = false
-	} else {
-		// Mute audio
 		player.SetVolume(0)
-		isMuted = true
+	} else {
+		player.SetVolume(settings.Volume)
 	}
 }
@@ -1,30 +1,80 @@
 package game
 
 import (
+	"image/color"
 	"log"
+	"math"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/smarty-archives/rooftop-geocoding-game/media"
 )
 
 type Layer struct {
-	Image   *ebiten.Image
-	Speed   float64 // Speed multiplier for parallax effect
-	OffsetX float64
+	Image      *ebiten.Image
+	Speed      float64 // Speed multiplier for parallax effect
+	OffsetX    float64
+	OffsetY    float64
+	Tint       color.NRGBA
+	Tiling     string
+	Foreground bool
 }
 
+// NewLayers builds the background from the layers in assets/backgrounds.json, back to front
 func NewLayers() []Layer {
 	var layers []Layer
-	speeds := []float64{0.2, 0.5} // Warning: if there are fewer speeds than background layers, then this will panic
-	for i := range media.NumBackgroundLayers {
-		img, err := media.Instance.LoadBackgroundImage(i)
+	for _, config := range media.Instance.GetBackgroundLayers() {
+		img, err := media.Instance.LoadBackgroundImage(config.Image)
 		if err != nil {
 			log.Fatal(err)
 		}
 		layers = append(layers, Layer{
-			Image: img,
-			Speed: speeds[i],
+			Image:      img,
+			Speed:      config.Speed,
+			OffsetY:    config.OffsetY,
+			Tint:       config.TintColor,
+			Tiling:     config.Tiling,
+			Foreground: config.Foreground,
 		})
 	}
 	return layers
 }
+
+func (l *Layer) Draw(screen *ebiten.Image, timeOfDay ebiten.ColorScale) {
+	imgWidth := float64(l.Image.Bounds().Dx())
+	imgHeight := float64(l.Image.Bounds().Dy())
+
+	if l.Tiling == media.TilingStretch {
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Scale(screenWidth/imgWidth, screenHeight/imgHeight)
+		op.GeoM.Translate(0, l.OffsetY)
+		op.ColorScale.ScaleWithColor(l.Tint)
+		op.ColorScale.ScaleWithColorScale(timeOfDay)
+		screen.DrawImage(l.Image, op)
+		return
+	}
+
+	scale := 1.0
+	if l.Tiling == media.TilingFitHeight {
+		// Scale the image to match the screen height, maintaining the aspect ratio horizontally
+		scale = screenHeight / imgHeight
+	}
+
+	// Calculate the total width of a single scaled image
+	scaledWidth := imgWidth * scale
+
+	// Ensure the offset wraps around seamlessly
+	xOffset := math.Mod(l.OffsetX, scaledWidth)
+	if xOffset > 0 {
+		xOffset -= scaledWidth
+	}
+
+	// Draw enough images to cover the entire screen width
+	for x := xOffset; x < screenWidth; x += scaledWidth {
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Scale(scale, scale)
+		op.GeoM.Translate(x, l.OffsetY)
+		op.ColorScale.ScaleWithColor(l.Tint)
+		op.ColorScale.ScaleWithColorScale(timeOfDay)
+		screen.DrawImage(l.Image, op)
+	}
+}
@@ -0,0 +1,58 @@
+package game
+
+import (
+	"github.com/smarty-archives/rooftop-geocoding-game/race"
+)
+
+const (
+	botName          = "Smarty"
+	defaultBoothName = "Guest"
+)
+
+// Booth publishes every run played on this machine so spectators on the big screen can follow along
+type Booth struct {
+	client    *race.Client
+	booth     string
+	name      string
+	announced int // the run the spectators were last told about
+	sentFell  bool
+}
+
+// PublishRuns sends the seed and every frame of each run to the spectators watching the booth
+func (g *Game) PublishRuns(url, booth, name string) error {
+	client, err := race.Dial(url)
+	if err != nil {
+		return err
+	}
+	if name == "" {
+		name = tr(defaultBoothName)
+	}
+	g.booth = &Booth{client: client, booth: booth, name: name, announced: -1}
+	return nil
+}
+
+func (g *Game) handleBooth() {
+	b := g.booth
+	b.client.Poll() // the server never talks back to a booth, this just keeps the queue empty
+	if !g.gameStarted {
+		return
+	}
+	if b.announced != g.runs {
+		b.announced = g.runs
+		b.sentFell = false
+		name := b.name
+		if bot {
+			name = botName
+		}
+		b.client.Send(race.Message{Type: race.TypeRun, Room: b.booth, Name: name, Seed: g.seed})
+	}
+	if g.gameOver {
+		if !b.sentFell {
+			b.client.Send(race.Message{Type: race.TypeFell, Score: g.score})
+			b.sentFell = true
+		}
+		return
+	}
+	// spectators replay the run frame by frame, so every frame is sent
+	b.client.Send(race.Message{Type: race.TypeState, State: g.runnerState()})
+}
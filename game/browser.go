@@ -0,0 +1,118 @@
+//go:build js && wasm
+// +build js,wasm
+
+package game
+
+import "syscall/js"
+
+const raceServerPath = "race"
+
+// GetGameLink returns the link the page was templated with, falling back to the default link
+func GetGameLink() string {
+	link := js.Global().Get("gameLink")
+	if link.Type() != js.TypeString || link.String() == "" {
+		return defaultGameLink
+	}
+	return link.String()
+}
+
+// RaceConfigFromPage reads ?room=...&name=... from the page's URL and works out the race server's websocket URL
+func RaceConfigFromPage() (url, room, name string, ok bool) {
+	room = queryParam("room")
+	if room == "" {
+		return "", "", "", false
+	}
+	return raceServerURL(), room, queryParam("name"), true
+}
+
+// BoothConfigFromPage reads ?booth=...&name=... for the machines players use at the booth
+func BoothConfigFromPage() (url, booth, name string, ok bool) {
+	booth = queryParam("booth")
+	if booth == "" {
+		return "", "", "", false
+	}
+	return raceServerURL(), booth, queryParam("name"), true
+}
+
+// SpectatorConfigFromPage reads ?spectate=...&join=... for the big screen, joinURL is what the QR code points to
+func SpectatorConfigFromPage() (url, booth, joinURL string, ok bool) {
+	booth = queryParam("spectate")
+	if booth == "" {
+		return "", "", "", false
+	}
+	joinURL = queryParam("join")
+	if joinURL == "" {
+		joinURL = GetGameLink()
+	}
+	return raceServerURL(), booth, joinURL, true
+}
+
+// raceServerURL is the websocket URL of the race server hosted next to the game
+func raceServerURL() string {
+	serverURL := js.Global().Get("URL").New(raceServerPath, js.Global().Get("document").Get("baseURI"))
+	if serverURL.Get("protocol").String() == "https:" {
+		serverURL.Set("protocol", "wss:")
+	} else {
+		serverURL.Set("protocol", "ws:")
+	}
+	return serverURL.Call("toString").String()
+}
+
+// queryParam returns the value of the parameter in the page's URL, or "" if it isn't there
+func queryParam(key string) string {
+	params := js.Global().Get("URLSearchParams").New(js.Global().Get("location").Get("search"))
+	value := params.Call("get", key)
+	if value.IsNull() {
+		return ""
+	}
+	return value.String()
+}
+
+// preferredLanguages lists ?lang=... first, then the languages the browser asks for in order
+func preferredLanguages() []string {
+	var languages []string
+	if lang := queryParam("lang"); lang != "" {
+		languages = append(languages, lang)
+	}
+	navigator := js.Global().Get("navigator")
+	if list := navigator.Get("languages"); list.Truthy() {
+		for i := range list.Length() {
+			languages = append(languages, list.Index(i).String())
+		}
+	} else if lang := navigator.Get("language"); lang.Type() == js.TypeString {
+		languages = append(languages, lang.String())
+	}
+	return languages
+}
+
+func prefersReducedMotion() bool {
+	return matchesMedia("(prefers-reduced-motion: reduce)")
+}
+
+func prefersHighContrast() bool {
+	return matchesMedia("(prefers-contrast: more)")
+}
+
+func matchesMedia(query string) bool {
+	matchMedia := js.Global().Get("matchMedia")
+	if matchMedia.Type() != js.TypeFunction {
+		return false
+	}
+	return js.Global().Call("matchMedia", query).Get("matches").Truthy()
+}
+
+// onPageHidden calls fn whenever the player switches to another tab or app, until the returned function is called
+func onPageHidden(fn func()) (stop func()) {
+	document := js.Global().Get("document")
+	listener := js.FuncOf(func(this js.Value, args []js.Value) any {
+		if document.Get("hidden").Bool() {
+			fn()
+		}
+		return nil
+	})
+	document.Call("addEventListener", "visibilitychange", listener)
+	return func() {
+		document.Call("removeEventListener", "visibilitychange", listener)
+		listener.Release()
+	}
+}
@@ -0,0 +1,50 @@
+//go:build !js || !wasm
+// +build !js !wasm
+
+package game
+
+import (
+	"os"
+	"strings"
+)
+
+func GetGameLink() string {
+	return defaultGameLink
+}
+
+func RaceConfigFromPage() (url, room, name string, ok bool) {
+	return "", "", "", false
+}
+
+func BoothConfigFromPage() (url, booth, name string, ok bool) {
+	return "", "", "", false
+}
+
+func SpectatorConfigFromPage() (url, booth, joinURL string, ok bool) {
+	return "", "", "", false
+}
+
+// preferredLanguages reads the OS locale from the environment, turning es_MX.UTF-8 into es-MX
+func preferredLanguages() []string {
+	var languages []string
+	for _, key := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		locale, _, _ := strings.Cut(os.Getenv(key), ".")
+		if locale == "" || locale == "C" || locale == "POSIX" {
+			continue
+		}
+		languages = append(languages, strings.ReplaceAll(locale, "_", "-"))
+	}
+	return languages
+}
+
+func prefersReducedMotion() bool {
+	return false
+}
+
+func prefersHighContrast() bool {
+	return false
+}
+
+func onPageHidden(fn func()) (stop func()) {
+	return func() {}
+}
@@ -0,0 +1,123 @@
+package game
+
+import (
+	"image"
+	"image/color"
+	"math/rand"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/smarty-archives/rooftop-geocoding-game/media"
+)
+
+const (
+	roofHeadroom      = 30 // space above the roof in a building image for the props sticking up
+	buildingOverscan  = 20 // buildings go a little past the bottom of the screen so the shake never shows their feet
+	minBuildingWidth  = 2 * media.BuildingEdgeWidth
+	roofPropSpacing   = 60 // roughly one prop for every this many pixels of roof
+	antennaHeight     = 26
+	waterTowerWidth   = 18
+	waterTowerHeight  = 16
+	waterTowerLegs    = 8
+	airConditionerW   = 16
+	airConditionerH   = 8
+	chimneyWidth      = 8
+	chimneyHeight     = 14
+	roofPropEdgeSpace = 6 // keeps props from hanging off the edge of the roof
+)
+
+type roofPropKind int
+
+const (
+	antenna roofPropKind = iota
+	waterTower
+	airConditioner
+	chimney
+	numRoofPropKinds
+)
+
+type roofProp struct {
+	kind roofPropKind
+	x    float32
+}
+
+var (
+	colorProp        = color.RGBA{R: 70, G: 70, B: 80, A: 255}
+	colorPropVisited = color.RGBA{R: 40, G: 90, B: 160, A: 255}
+	colorPropLight   = color.RGBA{R: 230, G: 60, B: 50, A: 255}
+)
+
+// newRoofProps picks what sits on top of a building, the seed keeps it the same for the visited version
+func newRoofProps(width float64, seed int64) []roofProp {
+	rng := rand.New(rand.NewSource(seed))
+	var props []roofProp
+	for range rng.Intn(int(width)/roofPropSpacing + 1) {
+		kind := roofPropKind(rng.Intn(int(numRoofPropKinds)))
+		maxX := int(width) - 2*roofPropEdgeSpace - waterTowerWidth
+		props = append(props, roofProp{kind: kind, x: float32(roofPropEdgeSpace + rng.Intn(max(maxX, 1)))})
+	}
+	return props
+}
+
+// newBuildingImage puts a building together from the left edge, as many middle columns as fit and the right edge,
+// repeating the bottom floor until it reaches the bottom of the screen
+func newBuildingImage(pieces media.BuildingPieces, width, height int, props []roofProp, visited bool) *ebiten.Image {
+	img := ebiten.NewImage(width, height+roofHeadroom)
+	drawColumn(img, pieces.Left, 0)
+	for x := media.BuildingEdgeWidth; x < width-media.BuildingEdgeWidth; x += media.BuildingTileWidth {
+		middle := pieces.Middle
+		if remaining := width - media.BuildingEdgeWidth - x; remaining < media.BuildingTileWidth {
+			bounds := middle.Bounds()
+			middle = middle.SubImage(image.Rect(bounds.Min.X, bounds.Min.Y, bounds.Min.X+remaining, bounds.Max.Y)).(*ebiten.Image)
+		}
+		drawColumn(img, middle, x)
+	}
+	drawColumn(img, pieces.Right, width-media.BuildingEdgeWidth)
+	for _, prop := range props {
+		drawRoofProp(img, prop, visited)
+	}
+	return img
+}
+
+// drawColumn draws a piece under the headroom, then repeats its bottom floor until the image is full
+func drawColumn(dst, piece *ebiten.Image, x int) {
+	bounds := piece.Bounds()
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(float64(x), roofHeadroom)
+	dst.DrawImage(piece, op)
+
+	floor := piece.SubImage(image.Rect(bounds.Min.X, bounds.Max.Y-media.BuildingFloorHeight, bounds.Max.X, bounds.Max.Y)).(*ebiten.Image)
+	for y := roofHeadroom + bounds.Dy(); y < dst.Bounds().Dy(); y += media.BuildingFloorHeight {
+		op.GeoM.Reset()
+		op.GeoM.Translate(float64(x), float64(y))
+		dst.DrawImage(floor, op)
+	}
+}
+
+func drawRoofProp(dst *ebiten.Image, prop roofProp, visited bool) {
+	propColor := colorProp
+	if visited {
+		propColor = colorPropVisited
+	}
+	roof := float32(roofHeadroom)
+	x := prop.x
+	switch prop.kind {
+	case antenna:
+		vector.StrokeLine(dst, x, roof, x, roof-antennaHeight, 2, propColor, false)
+		vector.DrawFilledCircle(dst, x, roof-antennaHeight, 2, colorPropLight, true)
+	case waterTower:
+		legsTop := roof - waterTowerLegs
+		vector.StrokeLine(dst, x+3, roof, x+3, legsTop, 2, propColor, false)
+		vector.StrokeLine(dst, x+waterTowerWidth-3, roof, x+waterTowerWidth-3, legsTop, 2, propColor, false)
+		vector.DrawFilledRect(dst, x, legsTop-waterTowerHeight, waterTowerWidth, waterTowerHeight, propColor, false)
+	case airConditioner:
+		vector.DrawFilledRect(dst, x, roof-airConditionerH, airConditionerW, airConditionerH, propColor, false)
+	case chimney:
+		vector.DrawFilledRect(dst, x, roof-chimneyHeight, chimneyWidth, chimneyHeight, propColor, false)
+	}
+}
+
+// buildingHeight is how tall a building with its roof at y has to be to reach past the bottom of the screen
+func buildingHeight(y float64) int {
+	return int(screenHeight-y) + buildingOverscan
+}
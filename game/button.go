@@ -5,9 +5,9 @@ import (
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
-	"github.com/hajimehoshi/ebiten/v2/text"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
 	"github.com/hajimehoshi/ebiten/v2/vector"
-	"golang.org/x/image/font"
+	"github.com/smarty-archives/rooftop-geocoding-game/media"
 )
 
 type Button struct {
@@ -15,6 +15,7 @@ type Button struct {
 	width, height    float64
 	buttonFn         func()
 	isPressed        bool
+	focused          bool // a gamepad is on it
 	clickableMargin  float64
 	drawMoreStrategy ButtonDrawStrategy
 }
@@ -39,14 +40,67 @@ func NewTextButton(centerX, centerY, width, height float64, btnFunc func(), text
 	return b
 }
 
+// NewLabelButton shows whatever textFunc returns, so the label can change with the language or a setting
+func NewLabelButton(centerX, centerY, width, height float64, textFunc func() string, btnFunc func()) *Button {
+	b := NewButton(centerX, centerY, width, height, 0, btnFunc)
+	b.drawMoreStrategy = TextStrategy{
+		bg:       BaseStrategy{},
+		font:     NewFont(boldFontSource, buttonFontSize, TextPlain),
+		textFunc: textFunc,
+	}
+	return b
+}
+
+// NewToggleButton flips value every time it's pressed and shows whether it's on, onChange runs after every flip
+func NewToggleButton(centerX, centerY, width, height float64, label string, value *bool, onChange func()) *Button {
+	b := NewButton(centerX, centerY, width, height, 0, func() {
+		*value = !*value
+		onChange()
+	})
+	b.drawMoreStrategy = TextStrategy{
+		bg:   BaseStrategy{},
+		font: NewFont(regularFontSource, toggleFontSize, TextPlain),
+		textFunc: func() string {
+			if *value {
+				return tr("%s: On", tr(label))
+			}
+			return tr("%s: Off", tr(label))
+		},
+	}
+	return b
+}
+
 func NewImageButton(centerX, centerY, width, height, scale, clickableMargin float64, btnFunc func(), imageFunc func() *ebiten.Image) *Button {
 	b := NewButton(centerX, centerY, width, height, clickableMargin, btnFunc)
 	b.drawMoreStrategy = NewImageStrategy(imageFunc, scale)
 	return b
 }
 
+// NewTranslatedImageButton is an image button with words in the image, it shows textFunc as a label instead while
+// the image hasn't been translated to the player's language
+func NewTranslatedImageButton(centerX, centerY, width, height float64, btnFunc func(), imageFunc func() *ebiten.Image, textFunc func() string) *Button {
+	b := NewButton(centerX, centerY, width, height, 0, btnFunc)
+	b.drawMoreStrategy = TranslatedImageStrategy{
+		image: NewImageStrategy(imageFunc, 1),
+		label: TextStrategy{
+			bg:       BaseStrategy{},
+			font:     NewFont(boldFontSource, buttonFontSize, TextPlain),
+			textFunc: textFunc,
+		},
+	}
+	return b
+}
+
+func (b *Button) SetCenter(centerX, centerY float64) {
+	b.x = centerX - b.width/2
+	b.y = centerY - b.height/2
+}
+
 func (b *Button) Draw(screen *ebiten.Image) {
 	b.drawMoreStrategy.DrawButton(screen, b)
+	if b.focused {
+		drawFocusRing(screen, b)
+	}
 }
 
 func (b *Button) getJustPressed() bool {
@@ -71,9 +125,15 @@ func (b *Button) cursorOnButton() bool {
 	return b.Overlaps(x32, y32)
 }
 
+// press is what every way of pressing a button goes through, clicks, taps and gamepads
+func (b *Button) press() {
+	sounds.Play(media.SoundClick)
+	b.buttonFn()
+}
+
 func (b *Button) Update() {
 	if b.getJustPressed() {
-		b.buttonFn()
+		b.press()
 		b.isPressed = true
 	} else {
 		b.isPressed = false
@@ -100,27 +160,22 @@ func (bs BaseStrategy) DrawButton(screen *ebiten.Image, b *Button) {
 }
 
 type TextStrategy struct {
-	bg         ButtonDrawStrategy
-	font       font.Face
-	text       string
-	textWidth  int
-	textHeight int
+	bg       ButtonDrawStrategy
+	font     *Font
+	textFunc func() string
 }
 
-func NewTextStrategy(text string) ButtonDrawStrategy {
-	currFont := defaultFont
+func NewTextStrategy(content string) ButtonDrawStrategy {
 	return TextStrategy{
-		bg:         BaseStrategy{},
-		font:       currFont,
-		text:       text,
-		textWidth:  font.MeasureString(currFont, text).Ceil(),
-		textHeight: currFont.Metrics().Ascent.Ceil(),
+		bg:       BaseStrategy{},
+		font:     NewFont(boldFontSource, buttonFontSize, TextPlain),
+		textFunc: func() string { return content },
 	}
 }
 
 func (ts TextStrategy) DrawButton(screen *ebiten.Image, button *Button) {
 	ts.bg.DrawButton(screen, button)
-	text.Draw(screen, ts.text, ts.font, int(button.width/2+button.x)-(ts.textWidth/2), int(button.height/2+button.y)+(ts.textHeight/2), colorText)
+	ts.font.Draw(screen, ts.textFunc(), button.x+button.width/2, button.y+button.height/2, text.AlignCenter, 1)
 }
 
 type ImageStrategy struct {
@@ -142,3 +197,17 @@ func (is ImageStrategy) DrawButton(screen *ebiten.Image, button *Button) {
 	imageOptions.GeoM.Translate(button.x, button.y)
 	screen.DrawImage(image, imageOptions)
 }
+
+// TranslatedImageStrategy draws the image when its words are in the player's language, and the label when they aren't
+type TranslatedImageStrategy struct {
+	image ImageStrategy
+	label TextStrategy
+}
+
+func (ts TranslatedImageStrategy) DrawButton(screen *ebiten.Image, button *Button) {
+	if media.Instance.IsTranslated(ts.image.imageFunc()) {
+		ts.image.DrawButton(screen, button)
+	} else {
+		ts.label.DrawButton(screen, button)
+	}
+}
@@ -0,0 +1,110 @@
+package game
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	cameraSmoothing      = 0.15 // fraction of the way to the target covered every frame
+	cameraDeadZoneX      = 30   // how far the player can move from the look-ahead point before the camera follows
+	cameraLookAhead      = 80   // how far ahead of the player the camera looks when running at top speed
+	cameraLookAheadRate  = 0.05
+	cameraDeadZoneTop    = 80  // the camera only rises when the player gets closer than this to the top of the screen
+	maxCameraRise        = 240 // the camera never goes higher than this, or below the ground
+	hardLandingSpeed     = 20  // landing faster than this shakes the screen
+	shakePerLandingSpeed = 0.8
+	maxShake             = 8
+	shakeDecay           = 0.85
+)
+
+var cameraVerticalFollow = true
+
+// Camera is the view into the city, its position is the top left of the screen in world coordinates
+type Camera struct {
+	Pos
+	target         Pos
+	lookAhead      float64
+	shake          float64
+	shakeX, shakeY float64
+}
+
+func NewCamera() *Camera {
+	return &Camera{}
+}
+
+func (c *Camera) Reset() {
+	*c = Camera{}
+}
+
+// Follow moves the target the camera eases towards, minX keeps it from scrolling back past the city
+func (c *Camera) Follow(player *Player, minX float64) {
+	speed := player.velocityX / player.GetMaxPlayerSpeed()
+	c.lookAhead += (speed*cameraLookAhead - c.lookAhead) * cameraLookAheadRate
+
+	desiredX := player.GetCenterX() + c.lookAhead - screenWidth/2
+	if desiredX > c.target.x+cameraDeadZoneX {
+		c.target.x = desiredX - cameraDeadZoneX
+	} else if desiredX < c.target.x-cameraDeadZoneX {
+		c.target.x = desiredX + cameraDeadZoneX
+	}
+	c.target.x = max(c.target.x, minX, 0)
+
+	c.target.y = 0
+	if cameraVerticalFollow {
+		c.target.y = min(max(player.y-cameraDeadZoneTop, -maxCameraRise), 0)
+	}
+}
+
+func (c *Camera) Update() {
+	c.x += (c.target.x - c.x) * cameraSmoothing
+	c.y += (c.target.y - c.y) * cameraSmoothing
+	c.shake *= shakeDecay
+	if c.shake < 0.1 {
+		c.shake = 0
+	}
+	c.shakeX = (rand.Float64()*2 - 1) * c.shake
+	c.shakeY = (rand.Float64()*2 - 1) * c.shake
+}
+
+func (c *Camera) Shake(amount float64) {
+	if settings.ReducedMotion {
+		return
+	}
+	c.shake = math.Min(math.Max(c.shake, amount), maxShake)
+}
+
+// GeoM moves things from world coordinates onto the screen
+func (c *Camera) GeoM() ebiten.GeoM {
+	return c.Parallax(1)
+}
+
+// Parallax is GeoM for things in the distance that move slower than the camera
+func (c *Camera) Parallax(speed float64) ebiten.GeoM {
+	var geoM ebiten.GeoM
+	geoM.Translate(-c.x*speed+c.shakeX, -c.y*speed+c.shakeY)
+	return geoM
+}
+
+func (c *Camera) ToScreen(x, y float64) (float64, float64) {
+	geoM := c.GeoM()
+	return geoM.Apply(x, y)
+}
+
+// Left and Right are the edges of the screen in world coordinates, ignoring shake
+func (c *Camera) Left() float64 { return c.x }
+
+func (c *Camera) Right() float64 { return c.x + screenWidth }
+
+func (g *Game) handleCameraMovement() {
+	g.camera.Follow(g.player, g.getFirstPlatform().x-playerSize)
+}
+
+// handleHardLanding shakes the screen when the player comes down hard
+func (g *Game) handleHardLanding(velocityY float64) {
+	if velocityY > hardLandingSpeed {
+		g.camera.Shake((velocityY - hardLandingSpeed) * shakePerLandingSpeed)
+	}
+}
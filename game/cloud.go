@@ -23,22 +23,23 @@ func NewCloud(x, y float64, speed float64) *Cloud {
 	}
 }
 
-func (c *Cloud) Draw(screen *ebiten.Image, cameraX float64) {
+func (c *Cloud) Draw(screen *ebiten.Image, camera *Camera, timeOfDay ebiten.ColorScale) {
 	cloudOptions := &ebiten.DrawImageOptions{}
 	scaleX, scaleY := 1.0, 1.0
-	x := c.getOffsetX(cameraX)
 
 	cloudOptions.GeoM.Scale(scaleX, scaleY)
-	cloudOptions.GeoM.Translate(x, c.GetY())
+	cloudOptions.GeoM.Translate(c.GetX(), c.GetY())
+	cloudOptions.GeoM.Concat(camera.Parallax(c.Speed))
 
 	// Set transparency (alpha value between 0.0 and 1.0)
 	cloudOptions.ColorScale.Scale(1, 1, 1, c.Transparency)
+	cloudOptions.ColorScale.ScaleWithColorScale(timeOfDay)
 
 	screen.DrawImage(c.Image, cloudOptions)
 }
 
-func (c *Cloud) getOffsetX(cameraX float64) float64 {
-	return c.GetX() - cameraX*c.Speed
+func (c *Cloud) getOffsetX(camera *Camera) float64 {
+	return c.GetX() - camera.x*c.Speed
 }
 
 func pickRand[T any](items ...T) (winner T) {
@@ -0,0 +1,199 @@
+package game
+
+import (
+	"encoding/json"
+	"log"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+const controlsKey = "controls"
+
+// Action is something the player does, whatever key or button they do it with
+type Action int
+
+const (
+	MoveLeft Action = iota
+	MoveRight
+	Jump
+	Restart
+	Pause
+	Debug
+	Fullscreen
+	numActions
+	noAction Action = -1
+)
+
+// actionNames are the names the bindings are saved under and the labels on the rebinding screen
+var actionNames = [numActions]string{
+	MoveLeft:   "Move left",
+	MoveRight:  "Move right",
+	Jump:       "Jump",
+	Restart:    "Restart",
+	Pause:      "Pause",
+	Debug:      "Debug",
+	Fullscreen: "Fullscreen",
+}
+
+var defaultKeyBindings = [numActions][]ebiten.Key{
+	MoveLeft:   {ebiten.KeyLeft, ebiten.KeyA},
+	MoveRight:  {ebiten.KeyRight, ebiten.KeyD},
+	Jump:       {ebiten.KeySpace, ebiten.KeyUp, ebiten.KeyW},
+	Restart:    {ebiten.KeyEnter},
+	Pause:      {ebiten.KeyEscape, ebiten.KeyP},
+	Debug:      {ebiten.KeyR},
+	Fullscreen: {ebiten.KeyF},
+}
+
+// gamepadBindings aren't rebindable, the standard layout already puts them in the same place on every pad
+var gamepadBindings = [numActions][]ebiten.StandardGamepadButton{
+	Jump:    {gamepadJump},
+	Restart: {gamepadStart},
+	Pause:   {gamepadStart},
+}
+
+// KeyBindings are the keys for every action, saved next to the settings
+type KeyBindings [numActions][]ebiten.Key
+
+var keyBindings = loadKeyBindings()
+
+func loadKeyBindings() KeyBindings {
+	bindings := KeyBindings(defaultKeyBindings)
+	value, ok := loadValue(controlsKey)
+	if !ok {
+		return bindings
+	}
+	var saved map[string][]ebiten.Key
+	if err := json.Unmarshal([]byte(value), &saved); err != nil {
+		log.Println("Ignoring the saved controls:", err)
+		return bindings
+	}
+	for action, name := range actionNames {
+		if keys, ok := saved[name]; ok {
+			bindings[action] = keys
+		}
+	}
+	return bindings
+}
+
+func saveKeyBindings() {
+	saved := make(map[string][]ebiten.Key, numActions)
+	for action, name := range actionNames {
+		saved[name] = keyBindings[action]
+	}
+	data, err := json.Marshal(saved)
+	if err != nil {
+		log.Println("Not saving the controls:", err)
+		return
+	}
+	saveValue(controlsKey, string(data))
+}
+
+// bind makes key the only key for the action, taking it away from any other action that had it
+func (kb *KeyBindings) bind(action Action, key ebiten.Key) {
+	for other := range kb {
+		kb[other] = withoutKey(kb[other], key)
+	}
+	kb[action] = []ebiten.Key{key}
+	saveKeyBindings()
+}
+
+func (kb *KeyBindings) reset() {
+	*kb = defaultKeyBindings
+	saveKeyBindings()
+}
+
+func withoutKey(keys []ebiten.Key, key ebiten.Key) []ebiten.Key {
+	var without []ebiten.Key
+	for _, k := range keys {
+		if k != key {
+			without = append(without, k)
+		}
+	}
+	return without
+}
+
+// actionPressed is true for as long as any key or button for the action is held
+func actionPressed(action Action) bool {
+	for _, key := range keyBindings[action] {
+		if ebiten.IsKeyPressed(key) {
+			return true
+		}
+	}
+	for _, button := range gamepadBindings[action] {
+		if gamepads.Pressed(button) {
+			return true
+		}
+	}
+	if touches.held[action] {
+		return true
+	}
+	switch action {
+	case MoveLeft:
+		return gamepads.Horizontal() < 0
+	case MoveRight:
+		return gamepads.Horizontal() > 0
+	case Jump:
+		return isHeld && settings.TouchScheme == TouchOneTap
+	}
+	return false
+}
+
+// actionJustPressed is only true on the frame a key or button for the action goes down
+func actionJustPressed(action Action) bool {
+	for _, key := range keyBindings[action] {
+		if inpututil.IsKeyJustPressed(key) {
+			return true
+		}
+	}
+	for _, button := range gamepadBindings[action] {
+		if gamepads.JustPressed(button) {
+			return true
+		}
+	}
+	return touches.justPressed[action]
+}
+
+// bindingLabel lists the keys for an action the way they're printed on the keyboard
+func bindingLabel(action Action) string {
+	keys := keyBindings[action]
+	if len(keys) == 0 {
+		return tr("%s: none", tr(actionNames[action]))
+	}
+	names := make([]string, len(keys))
+	for i, key := range keys {
+		names[i] = strings.TrimPrefix(key.String(), "Arrow")
+	}
+	return tr("%s: %s", tr(actionNames[action]), strings.Join(names, ", "))
+}
+
+// updateRebinding waits for the key to bind to the action being rebound, Escape or a click gives up without changing
+// anything
+func (g *Game) updateRebinding() {
+	g.pressedKeys = inpututil.AppendJustPressedKeys(g.pressedKeys[:0])
+	for _, key := range g.pressedKeys {
+		if key != ebiten.KeyEscape {
+			keyBindings.bind(g.rebinding, key)
+		}
+		g.rebinding = noAction
+		return
+	}
+	if gamepads.JustPressed(gamepadBack) || inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		g.rebinding = noAction
+	}
+}
+
+func (g *Game) newRebindingButtons() []*Button {
+	var buttons []*Button
+	for action := range numActions {
+		buttons = append(buttons, newMenuButton(func() string {
+			if g.rebinding == action {
+				return tr("Press a key for %s", tr(actionNames[action]))
+			}
+			return bindingLabel(action)
+		}, func() { g.rebinding = action }))
+	}
+	return append(buttons, newMenuButton(translated("Reset to defaults"), keyBindings.reset))
+}
@@ -0,0 +1,139 @@
+package game
+
+import (
+	"image/color"
+	"math/rand"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	timeOfDayEasing = 0.01 // fraction of the way to the next time of day covered every frame
+	numStars        = 80
+	starsSpeed      = 0.05
+	starsMaxY       = screenHeight * 0.45
+	windowWidth     = 6
+	windowHeight    = 8
+	windowSpacingX  = 14
+	windowSpacingY  = 18
+	windowMargin    = 12
+	windowsLitRatio = 0.6
+)
+
+// TimeOfDay is how the scene is colored, it changes from morning to night as the score climbs
+type TimeOfDay struct {
+	r, g, b float32 // multiplied into the background, clouds and buildings
+	sky     float32 // opacity of the dusk sky gradient
+	stars   float32
+	windows float32 // opacity of the lit windows on visited buildings
+}
+
+var (
+	morning = TimeOfDay{r: 1, g: 1, b: 1}
+	dusk    = TimeOfDay{r: 1, g: .8, b: .7, sky: .25, windows: .4}
+	night   = TimeOfDay{r: .45, g: .5, b: .75, sky: .4, stars: 1, windows: 1}
+
+	colorSkyTop    = color.RGBA{R: 20, G: 24, B: 70, A: 255}
+	colorSkyBottom = color.RGBA{R: 250, G: 120, B: 80, A: 255}
+	colorWindowLit = color.RGBA{R: 255, G: 220, B: 120, A: 255}
+	colorStar      = color.NRGBA{R: 255, G: 255, B: 240, A: 255}
+
+	skyGradient *ebiten.Image
+	stars       []Pos
+)
+
+func (t TimeOfDay) lerp(target TimeOfDay, amount float32) TimeOfDay {
+	step := func(from, to float32) float32 { return from + (to-from)*amount }
+	return TimeOfDay{
+		r:       step(t.r, target.r),
+		g:       step(t.g, target.g),
+		b:       step(t.b, target.b),
+		sky:     step(t.sky, target.sky),
+		stars:   step(t.stars, target.stars),
+		windows: step(t.windows, target.windows),
+	}
+}
+
+func (t TimeOfDay) ColorScale() ebiten.ColorScale {
+	var scale ebiten.ColorScale
+	scale.Scale(t.r, t.g, t.b, 1)
+	return scale
+}
+
+func (g *Game) handleTimeOfDay() {
+	g.timeOfDay = g.timeOfDay.lerp(g.targetTimeOfDay(), timeOfDayEasing)
+}
+
+// drawSky darkens the sky behind the buildings with a gradient and stars as the day goes on
+func (g *Game) drawSky(screen *ebiten.Image) {
+	if g.timeOfDay.sky > 0 {
+		gradient := getSkyGradient()
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Scale(screenWidth, 1)
+		op.ColorScale.ScaleAlpha(g.timeOfDay.sky)
+		screen.DrawImage(gradient, op)
+	}
+	if g.timeOfDay.stars > 0.01 {
+		starColor := colorStar
+		starColor.A = uint8(255 * g.timeOfDay.stars)
+		for _, star := range getStars() {
+			x := float32(star.x - g.camera.x*starsSpeed)
+			for x < 0 {
+				x += maxScreenWidth
+			}
+			for x >= maxScreenWidth {
+				x -= maxScreenWidth
+			}
+			vector.DrawFilledRect(screen, x, float32(star.y), 2, 2, starColor, false)
+		}
+	}
+}
+
+// getSkyGradient is a one pixel wide image that gets stretched across the screen
+func getSkyGradient() *ebiten.Image {
+	if skyGradient != nil {
+		return skyGradient
+	}
+	skyGradient = ebiten.NewImage(1, screenHeight)
+	for y := range screenHeight {
+		progress := float64(y) / screenHeight
+		skyGradient.Set(0, y, color.RGBA{
+			R: mix(colorSkyTop.R, colorSkyBottom.R, progress),
+			G: mix(colorSkyTop.G, colorSkyBottom.G, progress),
+			B: mix(colorSkyTop.B, colorSkyBottom.B, progress),
+			A: 255,
+		})
+	}
+	return skyGradient
+}
+
+func mix(from, to uint8, progress float64) uint8 {
+	return uint8(float64(from) + (float64(to)-float64(from))*progress)
+}
+
+func getStars() []Pos {
+	if stars != nil {
+		return stars
+	}
+	rng := rand.New(rand.NewSource(1)) // the same sky every night
+	for range numStars {
+		stars = append(stars, Pos{x: rng.Float64() * maxScreenWidth, y: rng.Float64() * starsMaxY})
+	}
+	return stars
+}
+
+// newWindowsImage returns the lit windows for a building image
+func newWindowsImage(building *ebiten.Image) *ebiten.Image {
+	width, height := building.Bounds().Dx(), building.Bounds().Dy()
+	img := ebiten.NewImage(width, height)
+	rng := rand.New(rand.NewSource(int64(width)))
+	for y := roofHeadroom + windowMargin*2; y+windowHeight < height; y += windowSpacingY {
+		for x := windowMargin; x+windowWidth < width-windowMargin; x += windowSpacingX {
+			if rng.Float64() < windowsLitRatio {
+				vector.DrawFilledRect(img, float32(x), float32(y), windowWidth, windowHeight, colorWindowLit, false)
+			}
+		}
+	}
+	return img
+}
@@ -0,0 +1,83 @@
+package game
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	fullscreenIconSize    = 22
+	fullscreenIconMargin  = 6
+	fullscreenIconOffsetX = 116 // left of the pause button
+	fullscreenCornerSize  = 7
+	rotatePhoneWidth      = 90
+	rotatePhoneHeight     = 50
+)
+
+var colorRotateBackdrop = color.RGBA{R: 20, G: 24, B: 40, A: 255}
+
+func (g *Game) initFullscreenButton() {
+	g.fullscreenButton = NewButton(screenWidth-fullscreenIconOffsetX, 30, fullscreenIconSize, fullscreenIconSize, fullscreenIconMargin, toggleFullscreen)
+	g.fullscreenButton.drawMoreStrategy = FullscreenIconStrategy{}
+}
+
+// handleScreen takes care of the fullscreen key and button, pausing in portrait and keeping the screen awake during runs
+func (g *Game) handleScreen() {
+	g.fullscreenButton.Update()
+	if g.rebinding == noAction && actionJustPressed(Fullscreen) {
+		toggleFullscreen()
+	}
+	if g.isMobile && isPortrait() {
+		g.Pause()
+	}
+	running := g.gameStarted && !g.gameOver && !g.paused
+	setWakeLock(running || g.spectator != nil) // the big screen at the booth shouldn't go to sleep either
+}
+
+// drawRotateOverlay covers the game while a phone is held upright, the game doesn't fit that way
+func (g *Game) drawRotateOverlay(screen *ebiten.Image) {
+	if !g.isMobile || !isPortrait() {
+		return
+	}
+	vector.DrawFilledRect(screen, 0, 0, float32(screenWidth), screenHeight, colorRotateBackdrop, false)
+	cx, cy := float32(screenWidth/2), float32(screenHeight/2)
+	vector.StrokeRect(screen, cx-rotatePhoneWidth/2, cy-rotatePhoneHeight, rotatePhoneWidth, rotatePhoneHeight, 3, color.White, true)
+	vector.DrawFilledCircle(screen, cx+rotatePhoneWidth/2-8, cy-rotatePhoneHeight/2, 3, color.White, true)
+	font, _ := g.hud.fonts()
+	font.Draw(screen, tr("Turn your phone sideways to play"), screenWidth/2, screenHeight/2+hudLineHeight*2, text.AlignCenter, 1)
+}
+
+// FullscreenIconStrategy draws four corners pointing out, or pointing in when the game is already fullscreen
+type FullscreenIconStrategy struct {
+}
+
+func (fs FullscreenIconStrategy) DrawButton(screen *ebiten.Image, b *Button) {
+	left, top := float32(b.x), float32(b.y)
+	right, bottom := float32(b.x+b.width), float32(b.y+b.height)
+	corner := float32(fullscreenCornerSize)
+	if isFullscreen() {
+		corner = -corner // the corners turn inward
+	}
+	for _, c := range [][4]float32{
+		{left, top, 1, 1},
+		{right, top, -1, 1},
+		{left, bottom, 1, -1},
+		{right, bottom, -1, -1},
+	} {
+		x, y, dirX, dirY := c[0], c[1], c[2], c[3]
+		if corner < 0 { // move the corner's point inside the icon so it stays the same size
+			x += dirX * -corner
+			y += dirY * -corner
+		}
+		for _, line := range []struct {
+			width float32
+			clr   color.Color
+		}{{5, color.Black}, {2, color.White}} {
+			vector.StrokeLine(screen, x, y, x+dirX*corner, y, line.width, line.clr, true)
+			vector.StrokeLine(screen, x, y, x, y+dirY*corner, line.width, line.clr, true)
+		}
+	}
+}
@@ -1,111 +1,160 @@
 package game
 
 import (
-	"fmt"
 	"image/color"
-	"math"
 	"math/rand"
 	"slices"
 	"sort"
-	"strconv"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
-	"github.com/hajimehoshi/ebiten/v2/text"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
 	"github.com/smarty-archives/rooftop-geocoding-game/clipboard"
 	"github.com/smarty-archives/rooftop-geocoding-game/media"
-	"golang.org/x/image/font"
-	"golang.org/x/image/font/basicfont"
 )
 
 const (
-	screenWidth            = 640
-	screenHeight           = 480
-	startButtonCenterX     = screenWidth / 2
 	startButtonCenterY     = 400
+	shareButtonCenterY     = 400
 	playerSize             = 40
 	platformSpacing        = 100
 	maxYDeltaTop           = 120
 	minimumPlatformHeight  = 20
-	maxPlatformHeight      = 285 // this is a little bit less than the height of the building assets
+	maxPlatformHeight      = 285 // buildings can be drawn at any height, this keeps them jumpable
 	maxPlatformWidth       = 175
 	startingPlatformHeight = maxPlatformHeight
 	startingPlatformWidth  = maxPlatformWidth
-	startingPlatformX      = (screenWidth / 2) - (startingPlatformWidth / 2)
+	startingPlatformX      = (minScreenWidth / 2) - (startingPlatformWidth / 2) // the city can't depend on the screen size or races would differ
+	startingPlayerX        = startingPlatformX + startingPlatformWidth/2 - playerSize/2
+	platformLookahead      = maxScreenWidth / 2 // how close the last platform gets before another is generated
 	startingPlatformY      = screenHeight - startingPlatformHeight
 	lightGravity           = 0.4
 	gravity                = 0.7
 	heavyGravity           = 0.8
 
-	gameLink = "https://www.smarty.com/geocode-jumper"
+	defaultGameLink = "https://www.smarty.com/geocode-jumper"
 )
 
 var (
-	colorText                  = color.Black
-	bot                        = false
-	botFramesLeftJumping       = 0
-	debugMode                  = false
-	filler                 any = nil
-	filler2                any = nil
-	AudioInitialized           = false
-	isHeld                     = false
-	copiedSuccessCountdown     = 0
+	colorText              = color.Black
+	bot                    = false
+	botFramesLeftJumping   = 0
+	debugMode              = false
+	AudioInitialized       = false
+	isHeld                 = false
+	copiedSuccessCountdown = 0
 )
 
 type Game struct {
-	font             font.Face
-	backgroundLayers []Layer
-	platforms        []*Platform
-	clouds           []*Cloud
-	geocodes         []*Geocode
-	player           *Player
-	startButton      *Button
-	shareButton      *Button
-	muteButton       *Button
-	cameraX          float64
-	score            int
-	gameStarted      bool
-	gameOver         bool
-	isMobile         bool
-}
-
-func (g *Game) Layout(_, _ int) (int, int) { return screenWidth, screenHeight }
-
-var (
-	defaultFont = basicfont.Face7x13 // Use the default basic font from Ebiten
-)
+	font                 *Font
+	geocodeFont          *Font
+	contrastFont         *Font // geocodes in high contrast
+	backgroundLayers     []Layer
+	platforms            []*Platform
+	clouds               []*Cloud
+	geocodes             []*Geocode
+	player               *Player
+	race                 *Race
+	booth                *Booth
+	spectator            *Spectator
+	rng                  *rand.Rand // only used for the city so races can share a seed
+	seed                 int64
+	runs                 int // incremented every time the city is rebuilt
+	startButton          *Button
+	shareButton          *Button
+	muteButton           *Button
+	fullscreenButton     *Button
+	pauseButton          *Button
+	pauseMenus           [numPauseScreens][]*Button
+	pauseScreen          pauseScreen
+	paused               bool
+	rebinding            Action // the action waiting for a key on the controls screen, or noAction
+	pressedKeys          []ebiten.Key
+	accessibilityButtons []*Button
+	camera               *Camera
+	timeOfDay            TimeOfDay
+	weather              *Weather
+	particles            Particles
+	hud                  *HUD
+	minimap              *Minimap
+	score                int
+	gameStarted          bool
+	gameOver             bool
+	isMobile             bool
+	pointerHandler       *PointerHandler
+	stopPageHidden       func() // stops pausing when the tab is hidden, for Close
+	screenWatcher        *ScreenWatcher
+}
 
 func NewGame() *Game {
-	g := &Game{}
+	SetLanguage(detectLanguage())
+	g := &Game{camera: NewCamera(), timeOfDay: morning, weather: NewWeather(Clear, 0)}
+	g.seed = rand.Int63()
+	g.rng = rand.New(rand.NewSource(g.seed))
 	g.initClouds()
 	g.initPlatforms()
 	g.player = NewPlayer()
-	g.font = defaultFont
+	g.font = NewFont(regularFontSource, bodyFontSize, TextPlain)
+	g.geocodeFont = NewFont(regularFontSource, geocodeFontSize, TextOutline)
+	g.contrastFont = g.geocodeFont.HighContrast()
 	g.backgroundLayers = NewLayers()
+	g.hud = NewHUD()
+	g.minimap = NewMinimap()
+	applySettings()
 	g.initButtons()
+	g.initFullscreenButton()
+	g.initAccessibilityButtons()
 	g.isMobile = IsMobile()
+	g.screenWatcher = WatchScreen()
+	g.initPauseMenu()
+	touches.init()
 	if g.isMobile {
-		filler, filler2 = RegisterClickHandler(func(x, y int) {
+		g.pointerHandler = RegisterClickHandler(func(x, y int) {
+			if isPortrait() {
+				return // the rotate overlay is covering everything
+			}
 			if g.muteButton.Overlaps(x, y) {
-				g.muteButton.buttonFn()
+				g.muteButton.press()
+				return
+			}
+			if g.fullscreenButton.Overlaps(x, y) {
+				g.fullscreenButton.press() // straight from the tap, browsers only allow fullscreen from one
+				return
+			}
+			if g.tapPause(x, y) {
+				return
+			}
+			if !g.gameStarted && g.tapAccessibilityButton(x, y) {
+				return
+			}
+			if g.race != nil && (!g.gameStarted || g.gameOver) {
+				g.race.requestStart()
 				return
 			}
 			if !g.gameStarted {
 				g.gameStarted = true
 			} else if g.gameOver {
 				if g.shareButton.Overlaps(x, y) && !g.isMobile {
-					g.shareButton.buttonFn()
+					g.shareButton.press()
 				} else {
 					g.startOver()
 				}
 				return
 			}
-			g.Jump()
+			if settings.TouchScheme == TouchOneTap { // the other schemes jump with their own buttons and swipes
+				g.Jump()
+			}
 		})
 	}
 	return g
 }
 
+// Close lets go of everything the game registered with the browser
+func (g *Game) Close() {
+	g.pointerHandler.Release()
+	g.stopPageHidden()
+	g.screenWatcher.Release()
+}
+
 func (g *Game) initClouds() {
 	g.clouds = []*Cloud{
 		NewCloud(20, g.randomStartingCloudHeight(), .5),
@@ -140,6 +189,16 @@ func (g *Game) randomStartingCloudHeight() float64 {
 	return rand.Float64()*cloudRange*screenHeight + 20
 }
 
+// targetTimeOfDay moves the scene from morning to night as the score climbs, like the difficulty does
+func (g *Game) targetTimeOfDay() TimeOfDay {
+	if g.score > 60 {
+		return night
+	} else if g.score > 40 {
+		return dusk
+	}
+	return morning
+}
+
 func (g *Game) randomCloudHeight() float64 {
 	var cloudRange float64
 	if g.score > 90 {
@@ -157,37 +216,74 @@ func (g *Game) randomCloudHeight() float64 {
 func (g *Game) initPlatforms() {
 	g.platforms = []*Platform{NewPlatform(startingPlatformX, startingPlatformY, startingPlatformWidth)}
 	for i := 1; i < 2; i++ {
-		g.platforms = append(g.platforms, GenerateNewRandomPlatform(g.platforms[i-1], g.score))
+		g.platforms = append(g.platforms, GenerateNewRandomPlatform(g.platforms[i-1], g.rng))
 	}
 }
 
 func (g *Game) initButtons() {
-	g.startButton = NewImageButton(startButtonCenterX, startButtonCenterY, 187, 60, 1, 0, func() {
+	g.startButton = NewTranslatedImageButton(screenWidth/2, startButtonCenterY, 187, 60, func() {
+		if g.race != nil {
+			g.race.requestStart()
+			return
+		}
 		g.gameStarted = true
-	}, media.Instance.GetPlayButtonImage)
+	}, media.Instance.GetPlayButtonImage, translated("Play"))
 
-	g.shareButton = NewImageButton(startButtonCenterX, 400, 360, 60, 1, 0, func() {
-		clipboard.CopyToClipboard(fmt.Sprintf("I scored %d on Geocode Jumper!\nTry to beat me\n%s", g.score, gameLink))
+	g.shareButton = NewTranslatedImageButton(screenWidth/2, shareButtonCenterY, 360, 60, func() {
+		clipboard.CopyToClipboard(tr("I scored %d on Geocode Jumper!\nTry to beat me\n%s", g.score, GetGameLink()))
 		copiedSuccessCountdown = 120
-	}, GetShareButtonImage)
+	}, GetShareButtonImage, func() string {
+		if copiedSuccessCountdown > 0 {
+			return tr("Score copied!")
+		}
+		return tr("Copy your score to share it")
+	})
 
 	g.muteButton = NewImageButton(screenWidth-30, 30, 24, 24, .5, 20, func() {
 		ToggleMute()
 	}, GetMuteButtonImage)
 }
 
+// layoutButtons keeps the buttons anchored to the center and edges of the screen when its width changes
+func (g *Game) layoutButtons() {
+	g.startButton.SetCenter(screenWidth/2, startButtonCenterY)
+	g.shareButton.SetCenter(screenWidth/2, shareButtonCenterY)
+	g.muteButton.SetCenter(screenWidth-30, 30)
+	g.fullscreenButton.SetCenter(screenWidth-fullscreenIconOffsetX, 30)
+	g.layoutAccessibilityButtons()
+	g.layoutPauseMenu()
+	touches.layout()
+}
+
 ////////////////////////////////////////////////////////////////////////
 
 func (g *Game) Update() error {
+	g.handleScreen()
+	if g.spectator != nil {
+		g.updateSpectator()
+		return nil
+	}
 	g.debug()
+	if gamepads.Update() {
+		g.Pause() // don't let the player fall while they reach for the cable
+	}
+	touches.Update(g)
 	g.muteButton.Update()
+	if g.updatePause() {
+		return nil
+	}
 	g.handleBackgroundLayers()
 	g.handleBackgroundClouds()
+	g.handleTimeOfDay()
+	if g.race != nil {
+		g.handleRace()
+	}
 	if g.gameStarted {
 		g.handlePlatforms()
+		g.handleWeather()
 		g.checkGameOver()
 
-		// If game over, reset the game when the enter key is pressed
+		// If game over, reset the game when the restart key is pressed
 		if g.gameOver {
 			if !g.isMobile {
 				g.shareButton.Update()
@@ -195,14 +291,18 @@ func (g *Game) Update() error {
 					copiedSuccessCountdown--
 				}
 			}
-			if ebiten.IsKeyPressed(ebiten.KeyEnter) {
+			if g.race != nil {
+				if actionJustPressed(Restart) {
+					g.race.requestStart()
+				}
+			} else if actionJustPressed(Restart) {
 				if g.player.x < g.getFirstPlatform().GetX() {
 					bot = true
 				}
 				g.startOver()
 			}
 		} else {
-			if bot && inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
+			if bot && actionJustPressed(Restart) {
 				g.startOver()
 				bot = false
 			}
@@ -212,17 +312,32 @@ func (g *Game) Update() error {
 			g.handlePlatformCollision(prevLeft, prevRight)
 			g.handleScreenBounds()
 			g.handleCameraMovement()
+			g.hud.Update(g)
 		}
 		g.handleGeocodes()
+		g.particles.Update()
+		g.camera.Update()
+		g.minimap.Update(g.gameOver && !bot && g.race == nil)
 	} else { // Title Page
 		g.startButton.Update()
+		g.updateAccessibilityButtons()
+		if g.race != nil && actionJustPressed(Restart) {
+			g.race.requestStart()
+		} else if gamepads.JustPressed(gamepadStart) {
+			g.startButton.press()
+		} else {
+			gamepads.navigateMenu(append([]*Button{g.startButton}, g.accessibilityButtons...))
+		}
+	}
+	if g.booth != nil {
+		g.handleBooth()
 	}
 	return nil
 }
 
 func (g *Game) handleBackgroundLayers() {
 	for i := range g.backgroundLayers {
-		g.backgroundLayers[i].OffsetX = -g.cameraX * g.backgroundLayers[i].Speed
+		g.backgroundLayers[i].OffsetX = -g.camera.x * g.backgroundLayers[i].Speed
 	}
 }
 
@@ -236,7 +351,7 @@ func (g *Game) handleBackgroundClouds() {
 	}
 	// Cleanup
 	for i := range g.clouds {
-		if g.clouds[i].getOffsetX(g.cameraX) < -screenWidth {
+		if g.clouds[i].getOffsetX(g.camera) < -screenWidth {
 			g.clouds[i] = g.generateNewRandomCloud()
 		}
 	}
@@ -252,7 +367,7 @@ func (g *Game) getLastCloud() *Cloud {
 	}
 	lastCloud := g.clouds[0]
 	for i := range g.clouds {
-		if g.clouds[i].getOffsetX(g.cameraX) > lastCloud.getOffsetX(g.cameraX) {
+		if g.clouds[i].getOffsetX(g.camera) > lastCloud.getOffsetX(g.camera) {
 			lastCloud = g.clouds[i]
 		}
 	}
@@ -261,11 +376,12 @@ func (g *Game) getLastCloud() *Cloud {
 
 func (g *Game) handlePlatforms() {
 	// Generate New
-	if g.distToLastPlatform() < screenWidth/2 {
-		g.platforms = append(g.platforms, GenerateNewRandomPlatform(g.getLastPlatform(), g.score))
+	if g.distToLastPlatform() < platformLookahead {
+		g.platforms = append(g.platforms, GenerateNewRandomPlatform(g.getLastPlatform(), g.rng))
 	}
 	// Cleanup
 	if g.distToFirstPlatform() > screenWidth {
+		g.platforms[0].Deallocate()
 		g.platforms = g.platforms[1:]
 	}
 	for i := range g.platforms {
@@ -290,7 +406,7 @@ func (g *Game) distToFirstPlatform() float64 {
 }
 
 func (g *Game) debug() {
-	if ebiten.IsKeyPressed(ebiten.KeyR) {
+	if actionPressed(Debug) {
 		debugMode = true
 	} else {
 		debugMode = false
@@ -299,8 +415,11 @@ func (g *Game) debug() {
 
 // checkGameOver will set gameOver to true if Player fell too low
 func (g *Game) checkGameOver() {
-	if g.player.y >= screenHeight*2 {
+	if g.player.y >= screenHeight*2 && !g.gameOver {
 		g.gameOver = true
+		g.particles.emitFallStreak(g.player.x)
+		sounds.Play(media.SoundFall)
+		g.hud.recordScore(g.score)
 	}
 }
 
@@ -309,7 +428,14 @@ func (g *Game) getFirstPlatform() *Platform {
 }
 
 func (g *Game) startOver() {
+	g.startOverWithSeed(rand.Int63())
+}
+
+func (g *Game) startOverWithSeed(seed int64) {
 	g.resetGameState()
+	g.seed = seed
+	g.rng = rand.New(rand.NewSource(seed))
+	g.runs++
 	g.player.ResetPlayer()
 	g.initPlatforms()
 	g.initClouds()
@@ -317,10 +443,18 @@ func (g *Game) startOver() {
 
 // WARNING: getFirstPlatform will panic if you don't initialize the platforms after this
 func (g *Game) resetGameState() {
+	for _, p := range g.platforms {
+		p.Deallocate()
+	}
 	g.platforms = g.platforms[:0]
 	g.clouds = g.clouds[:0]
 	g.geocodes = g.geocodes[:0]
-	g.cameraX = 0
+	g.camera.Reset()
+	g.timeOfDay = morning
+	g.weather = NewWeather(Clear, 0)
+	g.particles.Clear()
+	g.hud.Reset()
+	g.minimap.Reset()
 	g.score = 0
 	g.gameOver = false
 	copiedSuccessCountdown = 0
@@ -329,25 +463,29 @@ func (g *Game) resetGameState() {
 func (g *Game) handlePlayer() {
 	g.player.cycleImage() // this needs to be here so the player image is updated consistently regardless of frame rate
 	// todo make bot and player implement interface that applyGravity can use instead of checking for jumping keys
-	if g.isMobile {
+	if g.isMobile && settings.TouchScheme == TouchOneTap {
 		if g.score > 0 { // wait until you land on the 1st building
 			g.player.AccelerateRight()
 		}
+		g.handleJumpBuffer()
 		g.applyGravity()
 	} else if bot {
 		g.botLogic()
 		g.applyBotGravity()
 	} else {
+		g.handleJumpBuffer()
 		g.playerControls()
 		g.applyGravity()
 	}
+	g.applyWind()
 }
 
 func (g *Game) botLogic() {
 	if g.botShouldAccelerateRight() {
 		g.player.AccelerateRight()
 	}
-	if g.playerCanJump() && g.botShouldJump() {
+	// the bot only jumps off solid roof, its jump model doesn't know about coyote time or buffered jumps
+	if !g.playerInAir() && g.botShouldJump() {
 		g.player.Jump()
 	}
 }
@@ -360,11 +498,29 @@ func (g *Game) botShouldAccelerateRight() bool {
 	return true
 }
 
+// playerCanJump is true on a roof, and for a few frames of coyote time after running off one without jumping
 func (g *Game) playerCanJump() bool {
-	return !g.playerInAir()
+	if !g.playerInAir() {
+		return true
+	}
+	return !g.player.isJumping && g.player.framesInAir <= settings.CoyoteFrames
 }
 
+// Jump jumps right away if the player can, otherwise it holds on to the jump in case they're about to land
 func (g *Game) Jump() {
+	if g.playerCanJump() {
+		g.player.Jump()
+	} else {
+		g.player.jumpBufferLeft = settings.JumpBufferFrames
+	}
+}
+
+// handleJumpBuffer jumps as soon as the player lands if they pressed jump a little too early
+func (g *Game) handleJumpBuffer() {
+	if g.player.jumpBufferLeft == 0 {
+		return
+	}
+	g.player.jumpBufferLeft--
 	if g.playerCanJump() {
 		g.player.Jump()
 	}
@@ -381,7 +537,7 @@ func (g *Game) playerInAir() bool {
 
 func (g *Game) botShouldJump() bool {
 	platformPos := g.nextUnvisitedPlatform()
-	numFrames := (platformPos.x - g.player.x) / g.player.maxPlayerSpeed
+	numFrames := (platformPos.x - g.player.x) / (g.player.maxPlayerSpeed + g.windSpeed())
 	for i := range 30 {
 		newY, newVelocityY := g.heightAfterXFramesOfJumping(i, int(numFrames)+1)
 		if newY < platformPos.y && newVelocityY > 0 {
@@ -447,22 +603,25 @@ func (g *Game) applyBotGravity() {
 
 func (g *Game) playerControls() {
 	// Accelerate left and right
-	if ebiten.IsKeyPressed(ebiten.KeyLeft) || ebiten.IsKeyPressed(ebiten.KeyA) {
+	if actionPressed(MoveLeft) {
 		g.player.AccelerateLeft()
-	} else if ebiten.IsKeyPressed(ebiten.KeyRight) || ebiten.IsKeyPressed(ebiten.KeyD) {
+	} else if actionPressed(MoveRight) {
 		g.player.AccelerateRight()
 	} else {
 		g.slowPlayer()
 	}
 
-	// Jumping logic
-	if (ebiten.IsKeyPressed(ebiten.KeySpace) || ebiten.IsKeyPressed(ebiten.KeyUp) || ebiten.IsKeyPressed(ebiten.KeyW)) && !g.player.isJumping {
+	// Jumping logic, a jump pressed while still in the air gets buffered by Jump
+	if actionJustPressed(Jump) || actionPressed(Jump) && !g.player.isJumping {
 		g.Jump()
 	}
 }
 
 func (g *Game) slowPlayer() {
-	g.player.velocityX *= .8
+	g.player.velocityX *= 1 - g.player.GetTraction()
+	if g.player.GetTraction() < startingTraction { // wet roofs keep the player sliding
+		g.player.x += g.player.velocityX
+	}
 }
 
 func (g *Game) applyGravity() {
@@ -470,7 +629,7 @@ func (g *Game) applyGravity() {
 	currentGravity := gravity
 	if g.player.velocityY > 0 {
 		currentGravity = heavyGravity
-	} else if ebiten.IsKeyPressed(ebiten.KeySpace) || ebiten.IsKeyPressed(ebiten.KeyUp) || ebiten.IsKeyPressed(ebiten.KeyW) || isHeld {
+	} else if actionPressed(Jump) {
 		currentGravity = lightGravity
 	}
 	g.player.velocityY += currentGravity
@@ -478,18 +637,29 @@ func (g *Game) applyGravity() {
 }
 
 func (g *Game) handlePlatformCollision(prevLeft, prevRight float64) {
+	g.player.framesInAir++
 	for _, p := range g.platforms {
 		// **Vertical collision (Landing on the platform)**
 		if g.playerOnPlatform(*p) {
+			if g.player.velocityY > minDustLandingSpeed {
+				g.particles.emitLandingDust(g.player.GetCenterX(), p.y)
+			}
+			g.handleHardLanding(g.player.velocityY)
+			if g.player.velocityY > gravity { // standing still never gets faster than gravity
+				g.hud.onLanding(!p.visited)
+				sounds.Play(media.SoundLand)
+			}
 			// Land on the platform
 			g.player.y = p.y - playerSize
 			g.player.velocityY = 0
 			g.player.isJumping = false
+			g.player.framesInAir = 0
 
 			if !p.visited {
-				p.Visit()
+				p.Visit(g.player.GetCenterX())
 				g.score++
 				g.addGeocode()
+				sounds.Play(media.SoundGeocode)
 			}
 		}
 		platformLeft := p.x
@@ -520,24 +690,20 @@ func (g *Game) playerOnPlatform(p Platform) bool {
 }
 
 func (g *Game) addGeocode() {
-	g.geocodes = append(g.geocodes, NewGeocode(Pos{
+	g.particles.emitGeocodeBurst(g.player.GetCenterX(), g.player.GetY())
+	geocode := NewGeocode(Pos{
 		x: g.player.GetCenterX(),
 		y: g.player.GetY() - 20,
-	}))
+	})
+	g.geocodes = append(g.geocodes, geocode)
+	g.minimap.Add(geocode.lat, geocode.lon)
 }
 
 func (g *Game) handleScreenBounds() {
-	if g.player.x < g.cameraX {
-		g.player.x = g.cameraX
-	} else if g.player.x+playerSize > g.cameraX+screenWidth {
-		g.player.x = g.cameraX + screenWidth - playerSize
-	}
-}
-
-func (g *Game) handleCameraMovement() {
-	g.cameraX = max(g.player.x-screenWidth/2+playerSize/2, g.getFirstPlatform().x-playerSize)
-	if g.cameraX < 0 {
-		g.cameraX = 0
+	if g.player.x < g.camera.Left() {
+		g.player.x = g.camera.Left()
+	} else if g.player.x+playerSize > g.camera.Right() {
+		g.player.x = g.camera.Right() - playerSize
 	}
 }
 
@@ -560,13 +726,35 @@ func (g *Game) Draw(screen *ebiten.Image) {
 
 	if !g.gameStarted { // Title Page
 		g.drawBackgroundClouds(screen)
+		g.drawForegroundLayers(screen)
 		g.drawTitle(screen)
-		g.startButton.Draw(screen)
+		g.drawAccessibilityButtons(screen)
+		if g.race != nil {
+			g.drawRaceLobby(screen)
+		} else {
+			g.startButton.Draw(screen)
+		}
 	} else { // Game Started
 		g.drawPlatforms(screen)
-		g.player.Draw(screen, g.cameraX)
+		if g.race != nil {
+			g.drawGhosts(screen)
+		}
+		g.player.Draw(screen, g.camera)
+		g.particles.Draw(screen, g.camera)
 		g.drawBackgroundClouds(screen)
-		if g.gameOver {
+		g.drawForegroundLayers(screen)
+		g.weather.Draw(screen)
+		if g.spectator != nil {
+			g.drawGeocodes(screen)
+			g.drawSpectatorOverlay(screen)
+			return
+		}
+		if g.gameOver && g.race != nil {
+			g.drawRaceStandings(screen)
+		} else {
+			g.minimap.Draw(screen)
+		}
+		if g.gameOver && g.race == nil {
 			if !bot {
 				if !g.isMobile {
 					g.shareButton.Draw(screen)
@@ -579,39 +767,35 @@ func (g *Game) Draw(screen *ebiten.Image) {
 		}
 	}
 	g.muteButton.Draw(screen)
+	g.fullscreenButton.Draw(screen)
 	g.DrawAllText(screen)
+	touches.Draw(screen)
+	g.drawPause(screen)
+	g.drawRotateOverlay(screen)
 }
 
 func (g *Game) drawBackgroundLayers(screen *ebiten.Image) {
-	for _, layer := range g.backgroundLayers {
-		imgWidth := layer.Image.Bounds().Dx()
-		imgHeight := layer.Image.Bounds().Dy()
-
-		// Scale the image to match the screen height
-		scaleY := float64(screenHeight) / float64(imgHeight)
-		scaleX := scaleY // Maintain the aspect ratio horizontally
-
-		// Calculate the total width of a single scaled image
-		scaledWidth := float64(imgWidth) * scaleX
-
-		// Ensure the offset wraps around seamlessly
-		xOffset := layer.OffsetX
-		xOffset = math.Mod(xOffset, scaledWidth)
-		if xOffset > 0 {
-			xOffset -= scaledWidth
+	for i := range g.backgroundLayers {
+		if !g.backgroundLayers[i].Foreground {
+			g.backgroundLayers[i].Draw(screen, g.timeOfDay.ColorScale())
 		}
+	}
+	g.drawSky(screen)
+}
 
-		// Draw enough images to cover the entire screen width
-		for x := xOffset; x < screenWidth; x += scaledWidth {
-			op := &ebiten.DrawImageOptions{}
-			op.GeoM.Scale(scaleX, scaleY)
-			op.GeoM.Translate(x, 0)
-			screen.DrawImage(layer.Image, op)
+// drawForegroundLayers draws the layers that sit in front of the buildings, like haze
+func (g *Game) drawForegroundLayers(screen *ebiten.Image) {
+	for i := range g.backgroundLayers {
+		if g.backgroundLayers[i].Foreground {
+			g.backgroundLayers[i].Draw(screen, g.timeOfDay.ColorScale())
 		}
 	}
 }
 
 func (g *Game) drawBackgroundClouds(screen *ebiten.Image) {
+	if settings.ReducedMotion { // parallax can make players with vestibular disorders feel sick
+		return
+	}
 	speedMap := map[float64][]*Cloud{}
 	var speeds []float64
 	for i := range g.clouds {
@@ -624,58 +808,70 @@ func (g *Game) drawBackgroundClouds(screen *ebiten.Image) {
 	sort.Float64s(speeds)
 	for _, speed := range speeds {
 		for _, cloud := range speedMap[speed] {
-			cloud.Draw(screen, g.cameraX)
+			cloud.Draw(screen, g.camera, g.cloudColorScale())
 		}
 	}
 }
 
+// cloudColorScale darkens the clouds for the time of day and the weather
+func (g *Game) cloudColorScale() ebiten.ColorScale {
+	scale := g.timeOfDay.ColorScale()
+	shade := g.weather.cloudShade
+	scale.Scale(shade, shade, shade, 1)
+	return scale
+}
+
 func (g *Game) drawTitle(screen *ebiten.Image) {
 	drawImage(screen, media.Instance.GetTitleImage(), screenWidth/2, screenHeight/2-50)
 }
 
 func (g *Game) drawGameOverScreen(screen *ebiten.Image) {
 	var restartImage *ebiten.Image
+	var restartText string
 	if g.isMobile {
 		restartImage = media.Instance.GetMobileRestartButtonImage()
+		restartText = tr("Tap to restart")
 	} else {
 		restartImage = media.Instance.GetRestartButtonImage()
+		restartText = tr("Press enter to restart")
+	}
+	if !media.Instance.IsTranslated(restartImage) {
+		font, _ := g.hud.fonts()
+		font.Draw(screen, restartText, screenWidth/2, screenHeight/2, text.AlignCenter, 1)
+		return
 	}
 	drawImage(screen, restartImage, screenWidth/2, screenHeight/2)
 }
 
 func (g *Game) drawBotScreen(screen *ebiten.Image) {
-	g.drawTextCenteredOn(screen, "Smarty will take it from here.", screenWidth/2, 60)
-	g.drawTextCenteredOn(screen, "Press enter if you want to go back to the hard way.", screenWidth/2, 80)
+	g.drawTextCenteredOn(screen, tr("Smarty will take it from here."), int(screenWidth)/2, 60)
+	g.drawTextCenteredOn(screen, tr("Press enter if you want to go back to the hard way."), int(screenWidth)/2, 80)
 }
 
 func (g *Game) drawTextCenteredOn(screen *ebiten.Image, content string, x, y int) {
-	textWidth := font.MeasureString(g.font, content).Ceil()
-	textHeight := g.font.Metrics().Ascent.Ceil()
-	drawX := x - textWidth/2
-	drawY := y - textHeight/2
-	text.Draw(screen, content, g.font, drawX, drawY, colorText)
+	g.font.Draw(screen, content, float64(x), float64(y), text.AlignCenter, 1)
 }
 
 func (g *Game) drawPlatforms(screen *ebiten.Image) {
 	for _, p := range g.platforms {
-		p.Draw(screen, g.cameraX)
+		p.Draw(screen, g.camera, g.timeOfDay)
 	}
 }
 
 func (g *Game) drawGeocodes(screen *ebiten.Image) {
+	font := g.geocodeFont
+	if settings.HighContrast {
+		font = g.contrastFont
+	}
 	for _, geocode := range g.geocodes {
-		geocode.Draw(screen, g.font, g.cameraX)
+		geocode.Draw(screen, font, g.camera)
 	}
 }
 
-func (g *Game) drawScore(screen *ebiten.Image) {
-	text.Draw(screen, "Rooftops Geocoded: "+strconv.Itoa(g.score), g.font, 10, 20, colorText)
-}
-
 func (g *Game) DrawAllText(screen *ebiten.Image) {
 	if g.gameStarted {
 		g.drawGeocodes(screen)
-		g.drawScore(screen)
+		g.hud.Draw(screen, g)
 	}
 }
 
@@ -0,0 +1,176 @@
+package game
+
+import (
+	"image/color"
+	"log"
+	"math"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	stickDeadZone  = .3
+	focusRingWidth = 3
+	focusRingGap   = 3
+)
+
+var colorFocusRing = color.RGBA{R: 0, G: 120, B: 255, A: 255}
+
+// fallbackButtons are used for pads ebiten has no standard mapping for, most of them follow the XInput order
+var fallbackButtons = map[ebiten.StandardGamepadButton]ebiten.GamepadButton{
+	ebiten.StandardGamepadButtonRightBottom: ebiten.GamepadButton0,
+	ebiten.StandardGamepadButtonRightRight:  ebiten.GamepadButton1,
+	ebiten.StandardGamepadButtonCenterRight: ebiten.GamepadButton7,
+}
+
+// the buttons the game listens to
+const (
+	gamepadJump  = ebiten.StandardGamepadButtonRightBottom // A on Xbox and Steam Deck layouts, cross on PlayStation
+	gamepadBack  = ebiten.StandardGamepadButtonRightRight
+	gamepadStart = ebiten.StandardGamepadButtonCenterRight
+)
+
+// Gamepads keeps track of the connected controllers, any of them can play
+type Gamepads struct {
+	ids        []ebiten.GamepadID
+	connected  []ebiten.GamepadID // reused every frame
+	focus      int                // the menu button the d-pad is on
+	menu       *Button            // the first button of the menu focus is in, to notice when the menu changes
+	stickStepY int                // the direction the stick was pushed last frame, so holding it only moves one button
+}
+
+var gamepads Gamepads
+
+// Update picks up controllers as they're plugged in and out, it reports whether one was unplugged
+func (gp *Gamepads) Update() (disconnected bool) {
+	gp.connected = inpututil.AppendJustConnectedGamepadIDs(gp.connected[:0])
+	for _, id := range gp.connected {
+		log.Printf("Gamepad connected: %s (standard layout: %t)", ebiten.GamepadName(id), ebiten.IsStandardGamepadLayoutAvailable(id))
+		gp.ids = append(gp.ids, id)
+	}
+	gp.ids = slices.DeleteFunc(gp.ids, func(id ebiten.GamepadID) bool {
+		if inpututil.IsGamepadJustDisconnected(id) {
+			log.Println("Gamepad disconnected")
+			disconnected = true
+			return true
+		}
+		return false
+	})
+	return disconnected
+}
+
+func (gp *Gamepads) Connected() bool {
+	return len(gp.ids) > 0
+}
+
+func (gp *Gamepads) Pressed(button ebiten.StandardGamepadButton) bool {
+	for _, id := range gp.ids {
+		if ebiten.IsStandardGamepadLayoutAvailable(id) {
+			if ebiten.IsStandardGamepadButtonPressed(id, button) {
+				return true
+			}
+		} else if fallback, ok := fallbackButtons[button]; ok && ebiten.IsGamepadButtonPressed(id, fallback) {
+			return true
+		}
+	}
+	return false
+}
+
+func (gp *Gamepads) JustPressed(button ebiten.StandardGamepadButton) bool {
+	for _, id := range gp.ids {
+		if ebiten.IsStandardGamepadLayoutAvailable(id) {
+			if inpututil.IsStandardGamepadButtonJustPressed(id, button) {
+				return true
+			}
+		} else if fallback, ok := fallbackButtons[button]; ok && inpututil.IsGamepadButtonJustPressed(id, fallback) {
+			return true
+		}
+	}
+	return false
+}
+
+// stick is the left stick, or the first two axes of a pad without a standard layout, with the dead zone cut out
+func (gp *Gamepads) stick() (x, y float64) {
+	for _, id := range gp.ids {
+		if ebiten.IsStandardGamepadLayoutAvailable(id) {
+			x = ebiten.StandardGamepadAxisValue(id, ebiten.StandardGamepadAxisLeftStickHorizontal)
+			y = ebiten.StandardGamepadAxisValue(id, ebiten.StandardGamepadAxisLeftStickVertical)
+		} else if ebiten.GamepadAxisCount(id) >= 2 {
+			x, y = ebiten.GamepadAxisValue(id, 0), ebiten.GamepadAxisValue(id, 1)
+		}
+		if math.Abs(x) > stickDeadZone || math.Abs(y) > stickDeadZone {
+			return x, y
+		}
+	}
+	return 0, 0
+}
+
+// Horizontal is -1 to run left, 1 to run right and 0 to slow down
+func (gp *Gamepads) Horizontal() int {
+	x, _ := gp.stick()
+	switch {
+	case gp.Pressed(ebiten.StandardGamepadButtonLeftLeft) || x < -stickDeadZone:
+		return -1
+	case gp.Pressed(ebiten.StandardGamepadButtonLeftRight) || x > stickDeadZone:
+		return 1
+	}
+	return 0
+}
+
+// verticalStep is -1 or 1 on the frame the d-pad or stick is pushed up or down, and 0 the rest of the time
+func (gp *Gamepads) verticalStep() int {
+	_, y := gp.stick()
+	stickStep := 0
+	if y < -stickDeadZone {
+		stickStep = -1
+	} else if y > stickDeadZone {
+		stickStep = 1
+	}
+	pushed := stickStep != gp.stickStepY
+	gp.stickStepY = stickStep
+
+	switch {
+	case gp.JustPressed(ebiten.StandardGamepadButtonLeftTop):
+		return -1
+	case gp.JustPressed(ebiten.StandardGamepadButtonLeftBottom):
+		return 1
+	case pushed:
+		return stickStep
+	}
+	return 0
+}
+
+// navigateMenu moves the focus through the buttons with the d-pad or stick and presses the focused one with A
+func (gp *Gamepads) navigateMenu(buttons []*Button) {
+	if len(buttons) == 0 {
+		return
+	}
+	if buttons[0] != gp.menu {
+		gp.menu = buttons[0]
+		gp.focus = 0
+	}
+	gp.focus = (gp.focus + gp.verticalStep() + len(buttons)) % len(buttons)
+	for i, b := range buttons {
+		b.focused = gp.Connected() && i == gp.focus
+	}
+	if gp.JustPressed(gamepadJump) {
+		buttons[gp.focus].press()
+	}
+}
+
+// resetFocus puts the focus back on the first button the next time a menu opens
+func (gp *Gamepads) resetFocus() {
+	gp.menu = nil
+}
+
+func drawFocusRing(screen *ebiten.Image, b *Button) {
+	vector.StrokeRect(screen,
+		float32(b.x-focusRingGap),
+		float32(b.y-focusRingGap),
+		float32(b.width+2*focusRingGap),
+		float32(b.height+2*focusRingGap),
+		focusRingWidth, colorFocusRing, true)
+}
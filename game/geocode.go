@@ -1,48 +1,53 @@
 package game
 
 import (
-	"fmt"
-	"image/color"
 	"math/rand"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/text"
-	"golang.org/x/image/font"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
 )
 
 const (
-	minLat = 30.0
-	maxLat = 50.0
-	minLon = -120.0
-	maxLon = -70.0
+	minLat         = 24.5 // the corners of the continental US
+	maxLat         = 49.5
+	minLon         = -124.8
+	maxLon         = -66.9
+	geocodeRetries = 100 // most random points in the box are on land, this is plenty
 )
 
 type Geocode struct {
-	str     string
-	opacity int
+	str      string
+	opacity  int
+	lat, lon float32
 	Pos
 }
 
 func NewGeocode(pos Pos) *Geocode {
 	lat, lon := randomGeocode()
 	return &Geocode{
-		str:     fmt.Sprintf("%f, %f", lat, lon),
+		str:     tr("%f, %f", lat, lon),
 		opacity: 300,
+		lat:     lat,
+		lon:     lon,
 		Pos:     pos,
 	}
 }
 
-func (g *Geocode) Draw(screen *ebiten.Image, fontObj font.Face, cameraX float64) {
-	textWidth := font.MeasureString(fontObj, g.str).Ceil()
-	textHeight := fontObj.Metrics().Ascent.Ceil()
-	drawX := int(g.x) - textWidth/2
-	drawY := int(g.y) - textHeight/2
+func (g *Geocode) Draw(screen *ebiten.Image, font *Font, camera *Camera) {
+	x, y := camera.ToScreen(g.x, g.y)
 	opacity := min(g.opacity, 255)
-	text.Draw(screen, g.str, fontObj, drawX-int(cameraX), drawY, color.RGBA{A: uint8(opacity)})
+	font.Draw(screen, g.str, x, y, text.AlignCenter, float32(opacity)/255)
 }
 
-func randomGeocode() (float32, float32) {
-	return randomFloat32(minLat, maxLat), randomFloat32(minLon, maxLon)
+// randomGeocode picks a point inside the US outline so it lands on the map
+func randomGeocode() (lat, lon float32) {
+	for range geocodeRetries {
+		lat, lon = randomFloat32(minLat, maxLat), randomFloat32(minLon, maxLon)
+		if insideUS(float64(lat), float64(lon)) {
+			break
+		}
+	}
+	return lat, lon
 }
 
 func randomFloat32(min, max float32) float32 {
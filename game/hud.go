@@ -0,0 +1,155 @@
+package game
+
+import (
+	"image/color"
+	"strconv"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/smarty-archives/rooftop-geocoding-game/media"
+)
+
+const (
+	hudMargin       = 10
+	hudLineHeight   = 22
+	hudRightInset   = 140 // leaves room for the fullscreen, pause and mute buttons in the top right corner
+	speedBarWidth   = 100
+	speedBarHeight  = 8
+	toastFrames     = 150
+	toastFadeFrames = 30
+	minStreak       = 2 // a streak of one isn't worth showing
+	cityBlockWidth  = 320
+	bestScoreKey    = "bestScore"
+)
+
+var (
+	colorSpeedBarBack = color.NRGBA{R: 255, G: 255, B: 255, A: 160}
+	colorSpeedBar     = color.RGBA{R: 0, G: 120, B: 255, A: 255}
+	colorSpeedBarMax  = color.RGBA{R: 255, G: 140, B: 0, A: 255}
+)
+
+// HUD shows how the run is going, anchored to the edges of the screen so it follows the layout width
+type HUD struct {
+	font              *Font
+	smallFont         *Font
+	contrastFont      *Font
+	contrastSmallFont *Font
+	best              int
+	beatBest          bool
+	furthestX         float64
+	streak            int
+	tier              int
+	toast             string
+	toastFrames       int
+}
+
+func NewHUD() *HUD {
+	h := &HUD{
+		font:      NewFont(boldFontSource, hudFontSize, TextOutline),
+		smallFont: NewFont(regularFontSource, bodyFontSize, TextOutline),
+	}
+	h.contrastFont = h.font.HighContrast()
+	h.contrastSmallFont = h.smallFont.HighContrast()
+	if value, ok := loadValue(bestScoreKey); ok {
+		h.best, _ = strconv.Atoi(value)
+	}
+	h.Reset()
+	return h
+}
+
+func (h *HUD) Reset() {
+	h.beatBest = false
+	h.furthestX = startingPlayerX
+	h.streak = 0
+	h.tier = 0
+	h.toastFrames = 0
+}
+
+func (h *HUD) Update(g *Game) {
+	h.furthestX = max(h.furthestX, g.player.x)
+	if tier := difficultyTier(g.score); tier > h.tier {
+		h.tier = tier
+		h.showToast(tr("Difficulty up! Level %d", tier+1))
+	}
+	if !bot && h.best > 0 && g.score > h.best && !h.beatBest {
+		h.beatBest = true
+		h.showToast(tr("New best score!"))
+	}
+	if h.toastFrames > 0 {
+		h.toastFrames--
+	}
+}
+
+func (h *HUD) showToast(message string) {
+	h.toast = message
+	h.toastFrames = toastFrames
+	sounds.Play(media.SoundMilestone)
+}
+
+// onLanding counts new rooftops landed on in a row, landing on a rooftop that was already geocoded ends the streak
+func (h *HUD) onLanding(newRooftop bool) {
+	if newRooftop {
+		h.streak++
+	} else {
+		h.streak = 0
+	}
+}
+
+// recordScore saves the score if it's the best one yet, the bot's runs don't count
+func (h *HUD) recordScore(score int) {
+	if bot || score <= h.best {
+		return
+	}
+	h.best = score
+	saveValue(bestScoreKey, strconv.Itoa(score))
+}
+
+func (h *HUD) fonts() (large, small *Font) {
+	if settings.HighContrast {
+		return h.contrastFont, h.contrastSmallFont
+	}
+	return h.font, h.smallFont
+}
+
+func (h *HUD) blocks() int {
+	return int((h.furthestX - startingPlayerX) / cityBlockWidth)
+}
+
+func (h *HUD) Draw(screen *ebiten.Image, g *Game) {
+	font, smallFont := h.fonts()
+	left := float64(hudMargin)
+	right := screenWidth - hudRightInset
+	font.Draw(screen, tr("Rooftops Geocoded: %d", g.score), left, 20, text.AlignStart, 1)
+	smallFont.Draw(screen, tr("Distance: %d blocks", h.blocks()), left, 20+hudLineHeight, text.AlignStart, 1)
+
+	smallFont.Draw(screen, tr("Best: %d", max(h.best, g.score)), right, 20, text.AlignEnd, 1)
+	if h.streak >= minStreak {
+		smallFont.Draw(screen, tr("Streak: %d in a row", h.streak), right, 20+hudLineHeight, text.AlignEnd, 1)
+	}
+
+	h.drawSpeed(screen, g.player, smallFont)
+
+	if h.toastFrames > 0 {
+		alpha := min(float32(h.toastFrames)/toastFadeFrames, 1)
+		font.Draw(screen, h.toast, screenWidth/2, screenHeight/4, text.AlignCenter, alpha)
+	}
+}
+
+// drawSpeed shows the player's speed as a fraction of their top speed in the bottom left corner, or under the
+// distance when the touch buttons have that corner
+func (h *HUD) drawSpeed(screen *ebiten.Image, player *Player, font *Font) {
+	speed := min(max(player.velocityX/player.GetMaxPlayerSpeed(), 0), 1)
+	x := float32(hudMargin)
+	y := float32(screenHeight - hudMargin - speedBarHeight)
+	if touches.shown {
+		y = 20 + 2.5*hudLineHeight
+	}
+	font.Draw(screen, tr("Speed"), hudMargin, float64(y)-hudLineHeight/2, text.AlignStart, 1)
+	barColor := colorSpeedBar
+	if speed >= 1 {
+		barColor = colorSpeedBarMax
+	}
+	vector.DrawFilledRect(screen, x, y, speedBarWidth, speedBarHeight, colorSpeedBarBack, false)
+	vector.DrawFilledRect(screen, x, y, speedBarWidth*float32(speed), speedBarHeight, barColor, false)
+}
@@ -0,0 +1,41 @@
+package game
+
+import "math"
+
+const (
+	screenHeight   = 480
+	minScreenWidth = 640
+	maxScreenWidth = 1280
+)
+
+// screenWidth grows with the window's aspect ratio so wide screens see more rooftops, the height never changes
+var screenWidth float64 = minScreenWidth
+
+func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
+	width := layoutWidth(float64(outsideWidth), float64(outsideHeight))
+	if width != screenWidth {
+		screenWidth = width
+		g.layoutButtons()
+	}
+	return int(screenWidth), screenHeight
+}
+
+// layoutWidth is the logical screen width that fills the display without letterboxing, within the supported range
+func layoutWidth(displayWidth, displayHeight float64) float64 {
+	if displayWidth <= 0 || displayHeight <= 0 {
+		return minScreenWidth
+	}
+	width := math.Round(displayWidth * screenHeight / displayHeight)
+	return min(max(width, minScreenWidth), maxScreenWidth)
+}
+
+// displayToScreen converts a point on the displayed canvas to game coordinates, the same way ebiten scales the Layout size
+func displayToScreen(x, y, displayWidth, displayHeight float64) (float64, float64) {
+	width := layoutWidth(displayWidth, displayHeight)
+	scale := min(displayWidth/width, displayHeight/screenHeight)
+
+	// anything left over is letterboxed evenly on both sides
+	offsetX := (displayWidth - width*scale) / 2
+	offsetY := (displayHeight - screenHeight*scale) / 2
+	return (x - offsetX) / scale, (y - offsetY) / scale
+}
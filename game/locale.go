@@ -0,0 +1,67 @@
+package game
+
+import (
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+
+	"github.com/smarty-archives/rooftop-geocoding-game/media"
+)
+
+var (
+	supportedLanguages = []language.Tag{language.English, language.Spanish} // the first one is the fallback
+	languageMatcher    = language.NewMatcher(supportedLanguages)
+	localizer          = newLocalizer(language.English)
+)
+
+// Localizer translates the player-facing strings and formats numbers the way the player's region writes them
+type Localizer struct {
+	tag     language.Tag
+	printer *message.Printer
+}
+
+func newLocalizer(tag language.Tag) *Localizer {
+	return &Localizer{tag: tag, printer: message.NewPrinter(tag)}
+}
+
+// detectLanguage picks the language the player chose in the settings, otherwise the best supported language for what
+// the browser or OS prefers, keeping the region for number formatting
+func detectLanguage() language.Tag {
+	tag, _ := language.MatchStrings(languageMatcher, append([]string{settings.Language}, preferredLanguages()...)...)
+	return tag
+}
+
+// nextLanguage is the supported language after the current one, going back to the first after the last
+func nextLanguage() language.Tag {
+	_, index, _ := languageMatcher.Match(localizer.tag)
+	return supportedLanguages[(index+1)%len(supportedLanguages)]
+}
+
+// SetLanguage switches the strings, the numbers and the localized prompt images
+func SetLanguage(tag language.Tag) {
+	localizer = newLocalizer(tag)
+	base, _ := tag.Base()
+	media.Instance.LocalizeImages(base.String())
+}
+
+func languageName(tag language.Tag) string {
+	base, _ := tag.Base()
+	switch base.String() {
+	case "es":
+		return "Español"
+	}
+	return "English"
+}
+
+// tr looks the English key up in the catalog, formatting the arguments for the current locale
+func tr(key string, args ...any) string {
+	return localizer.printer.Sprintf(key, args...)
+}
+
+// translated is for labels that are drawn every frame, so they follow the language when it changes
+func translated(key string) func() string {
+	return func() string { return tr(key) }
+}
+
+func formatNumber(n int) string {
+	return localizer.printer.Sprintf("%d", n)
+}
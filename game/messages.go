@@ -0,0 +1,89 @@
+package game
+
+import (
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// the English strings are the keys, so they only need to be listed here when they are translated
+var catalog = map[language.Tag]map[string]string{
+	language.Spanish: {
+		"Rooftops Geocoded: %d":                              "Tejados geocodificados: %d",
+		"I scored %d on Geocode Jumper!\nTry to beat me\n%s": "¡Conseguí %d puntos en Geocode Jumper!\nIntenta superarme\n%s",
+		"%f, %f":                         "%f; %f",
+		"Smarty will take it from here.": "Smarty se encarga desde aquí.",
+		"Press enter if you want to go back to the hard way.": "Pulsa Enter si quieres volver a jugar tú.",
+		"Press enter to restart":                              "Pulsa Enter para reiniciar",
+		"Tap to restart":                                      "Toca para reiniciar",
+		"Now playing: %s":                                     "Jugando: %s",
+		"Leaderboard":                                         "Clasificación",
+		"Scan to play!":                                       "¡Escanea para jugar!",
+		"Guest":                                               "Invitado",
+		"Runner":                                              "Corredor",
+		"Room %s: %d/%d runners":                              "Sala %s: %d/%d corredores",
+		"Race results":                                        "Resultados de la carrera",
+		"Waiting for %d runner(s) to fall...":                 "Esperando a que caigan %d corredor(es)...",
+		"Waiting for %s to start the race":                    "Esperando a que %s empiece la carrera",
+		"Waiting for more runners to join":                    "Esperando a más corredores",
+		"Tap to start the race":                               "Toca para empezar la carrera",
+		"Press enter to start the race":                       "Pulsa Enter para empezar la carrera",
+		"Difficulty up! Level %d":                             "¡Más difícil! Nivel %d",
+		"New best score!":                                     "¡Nuevo récord!",
+		"Distance: %d blocks":                                 "Distancia: %d cuadras",
+		"Best: %d":                                            "Récord: %d",
+		"Streak: %d in a row":                                 "Racha: %d seguidos",
+		"Your route: %d geocodes over %d miles":               "Tu ruta: %d geocódigos en %d millas",
+		"Your route: %d geocodes over %d km":                  "Tu ruta: %d geocódigos en %d km",
+		"Speed":                                               "Velocidad",
+		"%s: On":                                              "%s: Sí",
+		"%s: Off":                                             "%s: No",
+		"Reduced motion":                                      "Menos movimiento",
+		"High contrast":                                       "Alto contraste",
+		"Colorblind colors":                                   "Colores para daltónicos",
+		"Slower game":                                         "Juego más lento",
+		"Paused":                                              "En pausa",
+		"Resume":                                              "Continuar",
+		"Restart":                                             "Reiniciar",
+		"Settings":                                            "Ajustes",
+		"Quit to title":                                       "Salir al título",
+		"Volume: %d%%":                                        "Volumen: %d%%",
+		"Controls":                                            "Controles",
+		"Language: %s":                                        "Idioma: %s",
+		"Back":                                                "Volver",
+		"Move left":                                           "Mover a la izquierda",
+		"Move right":                                          "Mover a la derecha",
+		"Jump":                                                "Saltar",
+		"Pause":                                               "Pausa",
+		"Debug":                                               "Depurar",
+		"%s: none":                                            "%s: ninguna",
+		"Press a key for %s":                                  "Pulsa una tecla para %s",
+		"Reset to defaults":                                   "Restablecer controles",
+		"Jump timing help: %d frames":                         "Ayuda al saltar: %d fotogramas",
+		"Touch controls: %s":                                  "Controles táctiles: %s",
+		"One tap":                                             "Un toque",
+		"Buttons":                                             "Botones",
+		"Swipe":                                               "Deslizar",
+		"Rooftop reveal: %s":                                  "Revelar azoteas: %s",
+		"Sweep":                                               "Barrido",
+		"Radial":                                              "Radial",
+		"Scan lines":                                          "Líneas de escaneo",
+		"Pin drop":                                            "Caída de chincheta",
+		"Accessibility":                                       "Accesibilidad",
+		"Weather physics":                                     "Física del clima",
+		"Play":                                                "Jugar",
+		"Copy your score to share it":                         "Copia tu puntuación para compartirla",
+		"Score copied!":                                       "¡Puntuación copiada!",
+		"Fullscreen":                                          "Pantalla completa",
+		"Turn your phone sideways to play":                    "Gira el teléfono para jugar",
+	},
+}
+
+func init() {
+	for tag, messages := range catalog {
+		for key, translation := range messages {
+			if err := message.SetString(tag, key, translation); err != nil {
+				panic(err)
+			}
+		}
+	}
+}
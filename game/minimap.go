@@ -0,0 +1,225 @@
+package game
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"golang.org/x/text/language"
+)
+
+const (
+	minimapWidth      = 160
+	minimapHeight     = 100
+	minimapMargin     = 10
+	recapMarginX      = 30
+	recapTop          = 80 // leaves room for the HUD and the recap caption
+	recapBottom       = 30
+	recapExpandFrames = 40
+	minimapPinRadius  = 2
+	mapCenterLat      = 37 // the map is squashed horizontally by the cosine of this so the US isn't too wide
+	earthRadiusKm     = 6371
+	kmPerMile         = 1.609344
+)
+
+var (
+	colorMapBackdrop = color.NRGBA{R: 20, G: 24, B: 40, A: 200}
+	colorMapLand     = color.RGBA{R: 212, G: 212, B: 203, A: 230}
+	colorMapBorder   = color.RGBA{R: 90, G: 90, B: 100, A: 255}
+	colorMapPath     = color.RGBA{R: 0, G: 120, B: 255, A: 255}
+	colorMapPin      = colorPin
+
+	milesRegions = []language.Region{language.MustParseRegion("US"), language.MustParseRegion("GB")}
+)
+
+type LatLon struct {
+	lat, lon float64
+}
+
+// Minimap plots every geocode of the run on a map of the US, growing into a recap of the whole route at game over
+type Minimap struct {
+	points  []LatLon
+	expand  float64 // 0 is the inset in the corner, 1 is the full screen recap
+	font    *Font
+	outline mapOutline
+}
+
+// mapOutline is the US outline triangulated for the box it was last drawn in, it only changes while the map grows
+type mapOutline struct {
+	bounds                       [4]float64
+	fillVertices, strokeVertices []ebiten.Vertex
+	fillIndices, strokeIndices   []uint16
+}
+
+func NewMinimap() *Minimap {
+	return &Minimap{font: NewFont(boldFontSource, hudFontSize, TextOutline)}
+}
+
+func (m *Minimap) Reset() {
+	m.points = m.points[:0]
+	m.expand = 0
+}
+
+func (m *Minimap) Add(lat, lon float32) {
+	m.points = append(m.points, LatLon{lat: float64(lat), lon: float64(lon)})
+}
+
+// Update grows the map into the recap while recap is true, and snaps it back into the corner as soon as it isn't
+func (m *Minimap) Update(recap bool) {
+	switch {
+	case !recap:
+		m.expand = 0
+	case settings.ReducedMotion:
+		m.expand = 1
+	default:
+		m.expand = min(m.expand+1.0/recapExpandFrames, 1)
+	}
+}
+
+// bounds eases between the corner and the full screen
+func (m *Minimap) bounds() (x, y, width, height float64) {
+	insetX := screenWidth - minimapMargin - minimapWidth
+	if touches.shown { // the jump button has the corner
+		insetX = (screenWidth - minimapWidth) / 2
+	}
+	insetY := float64(screenHeight - minimapMargin - minimapHeight)
+	ease := m.expand * m.expand * (3 - 2*m.expand)
+	step := func(from, to float64) float64 { return from + (to-from)*ease }
+	return step(insetX, recapMarginX),
+		step(insetY, recapTop),
+		step(minimapWidth, screenWidth-2*recapMarginX),
+		step(minimapHeight, screenHeight-recapTop-recapBottom)
+}
+
+// projector fits the outline into the box keeping its proportions, it returns a function from lat, lon to the screen
+func projector(x, y, width, height float64) func(lat, lon float64) (float32, float32) {
+	squash := math.Cos(mapCenterLat * math.Pi / 180)
+	mapWidth := (maxLon - minLon) * squash
+	mapHeight := maxLat - minLat
+	scale := min(width/mapWidth, height/mapHeight)
+	offsetX := x + (width-mapWidth*scale)/2
+	offsetY := y + (height-mapHeight*scale)/2
+	return func(lat, lon float64) (float32, float32) {
+		return float32(offsetX + (lon-minLon)*squash*scale), float32(offsetY + (maxLat-lat)*scale)
+	}
+}
+
+func (m *Minimap) Draw(screen *ebiten.Image) {
+	x, y, width, height := m.bounds()
+	if m.expand > 0 {
+		backdrop := colorMapBackdrop
+		backdrop.A = uint8(float64(backdrop.A) * m.expand)
+		vector.DrawFilledRect(screen, 0, 0, float32(screenWidth), screenHeight, backdrop, false)
+	}
+	project := projector(x, y, width, height)
+	m.outline.Update(x, y, width, height, project)
+	drawPathTriangles(screen, m.outline.fillVertices, m.outline.fillIndices, colorMapLand, ebiten.FillRuleNonZero)
+	drawPathTriangles(screen, m.outline.strokeVertices, m.outline.strokeIndices, colorMapBorder, ebiten.FillRuleFillAll)
+
+	var route vector.Path
+	for i, point := range m.points {
+		px, py := project(point.lat, point.lon)
+		if i == 0 {
+			route.MoveTo(px, py)
+		} else {
+			route.LineTo(px, py)
+		}
+	}
+	strokePath(screen, &route, colorMapPath, float32(1+m.expand))
+
+	for i, point := range m.points {
+		px, py := project(point.lat, point.lon)
+		if i == len(m.points)-1 {
+			drawPin(screen, px, py, 1)
+		} else {
+			vector.DrawFilledCircle(screen, px, py, float32(minimapPinRadius*(1+m.expand)), colorMapPin, true)
+		}
+	}
+
+	if m.expand >= 1 {
+		m.font.Draw(screen, m.recapCaption(), screenWidth/2, recapTop-hudLineHeight/2, text.AlignCenter, 1)
+	}
+}
+
+// recapCaption sums up the route, in miles or kilometers depending on where the player is
+func (m *Minimap) recapCaption() string {
+	km := m.routeKm()
+	region, _ := localizer.tag.Region()
+	for _, milesRegion := range milesRegions {
+		if region == milesRegion {
+			return tr("Your route: %d geocodes over %d miles", len(m.points), int(km/kmPerMile))
+		}
+	}
+	return tr("Your route: %d geocodes over %d km", len(m.points), int(km))
+}
+
+// routeKm adds up the great circle distances between the geocodes
+func (m *Minimap) routeKm() float64 {
+	total := 0.0
+	for i := 1; i < len(m.points); i++ {
+		total += haversineKm(m.points[i-1], m.points[i])
+	}
+	return total
+}
+
+func haversineKm(from, to LatLon) float64 {
+	toRadians := func(degrees float64) float64 { return degrees * math.Pi / 180 }
+	dLat := toRadians(to.lat - from.lat)
+	dLon := toRadians(to.lon - from.lon)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(toRadians(from.lat))*math.Cos(toRadians(to.lat))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(a))
+}
+
+// Update triangulates the outline again when the box it's drawn in has moved or grown
+func (mo *mapOutline) Update(x, y, width, height float64, project func(lat, lon float64) (float32, float32)) {
+	bounds := [4]float64{x, y, width, height}
+	if mo.fillVertices != nil && bounds == mo.bounds {
+		return
+	}
+	mo.bounds = bounds
+	var path vector.Path
+	for i, point := range usOutline {
+		px, py := project(point[1], point[0])
+		if i == 0 {
+			path.MoveTo(px, py)
+		} else {
+			path.LineTo(px, py)
+		}
+	}
+	path.Close()
+	mo.fillVertices, mo.fillIndices = path.AppendVerticesAndIndicesForFilling(mo.fillVertices[:0], mo.fillIndices[:0])
+	mo.strokeVertices, mo.strokeIndices = path.AppendVerticesAndIndicesForStroke(mo.strokeVertices[:0], mo.strokeIndices[:0],
+		&vector.StrokeOptions{Width: 1, LineJoin: vector.LineJoinRound})
+}
+
+// fillPath draws a filled path in a single color, the path can be concave
+func fillPath(screen *ebiten.Image, path *vector.Path, clr color.Color) {
+	vertices, indices := path.AppendVerticesAndIndicesForFilling(nil, nil)
+	drawPathTriangles(screen, vertices, indices, clr, ebiten.FillRuleNonZero)
+}
+
+func strokePath(screen *ebiten.Image, path *vector.Path, clr color.Color, width float32) {
+	vertices, indices := path.AppendVerticesAndIndicesForStroke(nil, nil, &vector.StrokeOptions{Width: width, LineJoin: vector.LineJoinRound})
+	drawPathTriangles(screen, vertices, indices, clr, ebiten.FillRuleFillAll)
+}
+
+// drawPathTriangles draws the triangles of a path in one color. Every color that fades in or out in this package is a
+// color.NRGBA, because ebiten and the vector package read a color.RGBA as premultiplied, so scaling only its A leaves
+// it as bright as before. clr.RGBA() gives back premultiplied values whichever kind of color it is
+func drawPathTriangles(screen *ebiten.Image, vertices []ebiten.Vertex, indices []uint16, clr color.Color, fillRule ebiten.FillRule) {
+	r, g, b, a := clr.RGBA()
+	for i := range vertices {
+		vertices[i].SrcX, vertices[i].SrcY = 0, 0
+		vertices[i].ColorR = float32(r) / 0xffff
+		vertices[i].ColorG = float32(g) / 0xffff
+		vertices[i].ColorB = float32(b) / 0xffff
+		vertices[i].ColorA = float32(a) / 0xffff
+	}
+	screen.DrawTriangles(vertices, indices, pixelImage, &ebiten.DrawTrianglesOptions{
+		AntiAlias:      true,
+		FillRule:       fillRule,
+		ColorScaleMode: ebiten.ColorScaleModePremultipliedAlpha,
+	})
+}
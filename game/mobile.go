@@ -8,74 +8,107 @@ import (
 	"syscall/js"
 )
 
-const (
-	gameWidth  = screenWidth  // or whatever your game's pixel width is
-	gameHeight = screenHeight // same for height
-)
+// PointerHandler follows every finger on the canvas with Pointer Events, the jump is held for as long as any of them is down
+type PointerHandler struct {
+	canvas    js.Value
+	listeners jsListeners
+	pointers  map[int]bool // the ids of the fingers that are down
+}
 
-func RegisterClickHandler(fn func(x, y int)) (any, any) {
-	canvas := js.Global().Get("document").Call("querySelector", "canvas")
-	if canvas.IsUndefined() {
-		println("Canvas not found")
-		return nil, nil
-	}
-
-	callback := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
-		event := args[0]
-		touches := event.Get("touches")
-		if touches.Length() > 0 {
-			touch := touches.Index(0)
-			clientX := touch.Get("clientX").Float()
-			clientY := touch.Get("clientY").Float()
-
-			rect := canvas.Call("getBoundingClientRect")
-			canvasLeft := rect.Get("left").Float()
-			canvasTop := rect.Get("top").Float()
-			displayWidth := rect.Get("width").Float()
-			displayHeight := rect.Get("height").Float()
-
-			// Calculate scale factor to fit game inside display while preserving aspect ratio
-			scaleX := displayWidth / gameWidth
-			scaleY := displayHeight / gameHeight
-			scale := scaleX
-			if scaleY < scaleX {
-				scale = scaleY
-			}
+type jsListener struct {
+	target js.Value
+	event  string
+	fn     js.Func
+}
 
-			// Calculate actual size of the rendered game and its offset
-			renderedWidth := gameWidth * scale
-			renderedHeight := gameHeight * scale
-			offsetX := (displayWidth - renderedWidth) / 2
-			offsetY := (displayHeight - renderedHeight) / 2
-
-			// Coordinates relative to canvas
-			canvasX := clientX - canvasLeft
-			canvasY := clientY - canvasTop
-
-			// Check if inside game rendering area
-			//if canvasX < offsetX || canvasX > offsetX+renderedWidth ||
-			//	canvasY < offsetY || canvasY > offsetY+renderedHeight {
-			//	// Outside the game area — ignore
-			//	return nil
-			//}
-
-			// Convert to game coordinates
-			gameX := (canvasX - offsetX) / scale
-			gameY := (canvasY - offsetY) / scale
-			fn(int(gameX), int(gameY))
-			isHeld = true
-		}
+// jsListeners are event listeners that can all be taken off again, releasing the Go functions behind them
+type jsListeners []jsListener
+
+func (ls *jsListeners) listen(target js.Value, event string, handle func(event js.Value)) {
+	fn := js.FuncOf(func(this js.Value, args []js.Value) any {
+		handle(args[0])
 		return nil
 	})
+	target.Call("addEventListener", event, fn)
+	*ls = append(*ls, jsListener{target: target, event: event, fn: fn})
+}
 
-	releaseCallback := js.FuncOf(func(this js.Value, args []js.Value) any {
-		isHeld = false
+func (ls *jsListeners) release() {
+	for _, l := range *ls {
+		l.target.Call("removeEventListener", l.event, l.fn)
+		l.fn.Release()
+	}
+	*ls = nil
+}
+
+// RegisterClickHandler calls fn with the game coordinates of every finger that touches the canvas. It returns nil if
+// there's no canvas, Release is safe to call on nil
+func RegisterClickHandler(fn func(x, y int)) *PointerHandler {
+	canvas := js.Global().Get("document").Call("querySelector", "canvas")
+	if canvas.IsNull() || canvas.IsUndefined() {
+		println("Canvas not found")
 		return nil
+	}
+	// without this the browser takes over a finger that moves to scroll or zoom, cancelling the pointer
+	canvas.Get("style").Set("touchAction", "none")
+
+	h := &PointerHandler{canvas: canvas, pointers: map[int]bool{}}
+	h.listen(canvas, "pointerdown", func(event js.Value) {
+		if event.Get("pointerType").String() == "mouse" {
+			return // ebiten already handles the mouse, and a click would count twice
+		}
+		id := event.Get("pointerId").Int()
+		h.pointers[id] = true
+		isHeld = true
+		canvas.Call("setPointerCapture", id) // so the finger still gets let go of if it slides off the canvas
+		x, y := h.toScreen(event)
+		fn(int(x), int(y))
 	})
+	release := func(event js.Value) {
+		delete(h.pointers, event.Get("pointerId").Int())
+		isHeld = len(h.pointers) > 0
+	}
+	h.listen(canvas, "pointerup", release)
+	h.listen(canvas, "pointercancel", release) // a notification or the OS taking the touch over
+	h.listen(js.Global(), "blur", func(js.Value) { h.releaseAll() })
+	return h
+}
 
-	val := canvas.Call("addEventListener", "touchend", releaseCallback)
-	val2 := canvas.Call("addEventListener", "touchstart", callback)
-	return val, val2
+func (h *PointerHandler) listen(target js.Value, event string, handle func(event js.Value)) {
+	h.listeners.listen(target, event, handle)
+}
+
+func (h *PointerHandler) releaseAll() {
+	clear(h.pointers)
+	isHeld = false
+}
+
+// Release stops listening and frees the Go functions the browser was holding on to
+func (h *PointerHandler) Release() {
+	if h == nil {
+		return
+	}
+	h.listeners.release()
+	h.releaseAll()
+}
+
+// toScreen maps the event to game coordinates through the canvas's backing store, which is its CSS size times the
+// device pixel ratio, so taps line up with what ebiten draws even when the ratio isn't a whole number
+func (h *PointerHandler) toScreen(event js.Value) (float64, float64) {
+	rect := h.canvas.Call("getBoundingClientRect")
+	cssWidth, cssHeight := rect.Get("width").Float(), rect.Get("height").Float()
+	if cssWidth == 0 || cssHeight == 0 {
+		return 0, 0
+	}
+	pixelsX := h.canvas.Get("width").Float() / cssWidth
+	pixelsY := h.canvas.Get("height").Float() / cssHeight
+	if pixelsX == 0 || pixelsY == 0 {
+		ratio := js.Global().Get("devicePixelRatio").Float()
+		pixelsX, pixelsY = ratio, ratio
+	}
+	x := (event.Get("clientX").Float() - rect.Get("left").Float()) * pixelsX
+	y := (event.Get("clientY").Float() - rect.Get("top").Float()) * pixelsY
+	return displayToScreen(x, y, cssWidth*pixelsX, cssHeight*pixelsY)
 }
 
 func IsMobile() bool {
@@ -107,3 +140,142 @@ func IsMobile() bool {
 
 	return false
 }
+
+// wakeLock is the screen wake lock while there is one, the browser lets go of it by itself whenever the page is hidden
+var wakeLock struct {
+	sentinel js.Value
+	pending  bool
+	failed   bool // not supported or not allowed, so there's no point asking every frame
+}
+
+// screenState is kept up to date by the ScreenWatcher's listeners, so the game can check it every frame without
+// calling into JavaScript
+var screenState struct {
+	portrait, fullscreen bool
+}
+
+// ScreenWatcher follows the orientation and fullscreen changes until it's released
+type ScreenWatcher struct {
+	listeners jsListeners
+}
+
+func WatchScreen() *ScreenWatcher {
+	w := &ScreenWatcher{}
+	document := js.Global().Get("document")
+	updateFullscreen := func(js.Value) {
+		screenState.fullscreen = document.Get("fullscreenElement").Truthy() || document.Get("webkitFullscreenElement").Truthy()
+	}
+	updateFullscreen(js.Undefined())
+	w.listeners.listen(document, "fullscreenchange", updateFullscreen)
+	w.listeners.listen(document, "webkitfullscreenchange", updateFullscreen) // Safari
+
+	if js.Global().Get("matchMedia").Type() == js.TypeFunction {
+		portrait := js.Global().Call("matchMedia", "(orientation: portrait)")
+		screenState.portrait = portrait.Get("matches").Bool()
+		w.listeners.listen(portrait, "change", func(event js.Value) {
+			screenState.portrait = event.Get("matches").Bool()
+		})
+	}
+	return w
+}
+
+// Release is safe to call on nil
+func (w *ScreenWatcher) Release() {
+	if w == nil {
+		return
+	}
+	w.listeners.release()
+}
+
+func isFullscreen() bool {
+	return screenState.fullscreen
+}
+
+// toggleFullscreen goes through the Fullscreen API, browsers only allow it shortly after a tap, click or key press
+func toggleFullscreen() {
+	document := js.Global().Get("document")
+	if isFullscreen() {
+		if document.Get("exitFullscreen").Truthy() {
+			document.Call("exitFullscreen")
+		} else if document.Get("webkitExitFullscreen").Truthy() {
+			document.Call("webkitExitFullscreen")
+		}
+		return
+	}
+	element := document.Get("documentElement")
+	switch {
+	case element.Get("requestFullscreen").Truthy():
+		whenSettled(element.Call("requestFullscreen", map[string]any{"navigationUI": "hide"}), func(js.Value) {
+			if IsMobile() {
+				lockLandscape()
+			}
+		}, func(err js.Value) {
+			println("Can't go fullscreen:", err.Get("message").String())
+		})
+	case element.Get("webkitRequestFullscreen").Truthy(): // Safari, which doesn't return a promise
+		element.Call("webkitRequestFullscreen")
+	}
+}
+
+// lockLandscape only works in fullscreen, and not at all on some phones, the rotate overlay covers for those
+func lockLandscape() {
+	orientation := js.Global().Get("screen").Get("orientation")
+	if !orientation.Truthy() || !orientation.Get("lock").Truthy() {
+		return
+	}
+	whenSettled(orientation.Call("lock", "landscape"), nil, func(err js.Value) {
+		println("Can't lock the orientation:", err.Get("message").String())
+	})
+}
+
+func isPortrait() bool {
+	return screenState.portrait
+}
+
+// setWakeLock is called every frame with whether the screen should stay on, it only talks to the browser on changes
+func setWakeLock(on bool) {
+	if wakeLock.sentinel.Truthy() && wakeLock.sentinel.Get("released").Bool() {
+		wakeLock.sentinel = js.Undefined() // the page was hidden, ask again when it's back
+	}
+	switch {
+	case !on && wakeLock.sentinel.Truthy():
+		wakeLock.sentinel.Call("release")
+		wakeLock.sentinel = js.Undefined()
+	case on && !wakeLock.sentinel.Truthy() && !wakeLock.pending && !wakeLock.failed:
+		api := js.Global().Get("navigator").Get("wakeLock")
+		if !api.Truthy() {
+			wakeLock.failed = true
+			return
+		}
+		if js.Global().Get("document").Get("visibilityState").String() != "visible" {
+			return
+		}
+		wakeLock.pending = true
+		whenSettled(api.Call("request", "screen"), func(sentinel js.Value) {
+			wakeLock.pending = false
+			wakeLock.sentinel = sentinel
+		}, func(err js.Value) {
+			wakeLock.pending = false
+			wakeLock.failed = true
+			println("Can't keep the screen on:", err.Get("message").String())
+		})
+	}
+}
+
+// whenSettled calls onResolve or onReject with the promise's result, then releases both callbacks
+func whenSettled(promise js.Value, onResolve, onReject func(js.Value)) {
+	var resolve, reject js.Func
+	settle := func(fn func(js.Value), args []js.Value) any {
+		resolve.Release()
+		reject.Release()
+		if fn != nil && len(args) > 0 {
+			fn(args[0])
+		} else if fn != nil {
+			fn(js.Undefined())
+		}
+		return nil
+	}
+	resolve = js.FuncOf(func(this js.Value, args []js.Value) any { return settle(onResolve, args) })
+	reject = js.FuncOf(func(this js.Value, args []js.Value) any { return settle(onReject, args) })
+	promise.Call("then", resolve, reject)
+}
@@ -3,10 +3,41 @@
 
 package game
 
-func RegisterClickHandler(_ func(x, y int)) (any, any) {
-	return nil, nil
+import "github.com/hajimehoshi/ebiten/v2"
+
+type PointerHandler struct{}
+
+func RegisterClickHandler(_ func(x, y int)) *PointerHandler {
+	return nil
+}
+
+func (h *PointerHandler) Release() {
+}
+
+type ScreenWatcher struct{}
+
+func WatchScreen() *ScreenWatcher {
+	return nil
+}
+
+func (w *ScreenWatcher) Release() {
 }
 
 func IsMobile() bool {
 	return false
 }
+
+func isFullscreen() bool {
+	return ebiten.IsFullscreen()
+}
+
+func toggleFullscreen() {
+	ebiten.SetFullscreen(!ebiten.IsFullscreen())
+}
+
+func isPortrait() bool {
+	return false
+}
+
+func setWakeLock(on bool) {
+}
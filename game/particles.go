@@ -0,0 +1,189 @@
+package game
+
+import (
+	"image/color"
+	"math"
+	"math/rand"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	maxParticles         = 512
+	minDustLandingSpeed  = 2 // landing slower than this doesn't kick up dust
+	landingDustParticles = 8
+	geocodeBurstSize     = 24
+	fallStreakParticles  = 16
+	streakLength         = 3 // how many frames of movement a streak particle is stretched over
+	sparkleSize          = 5
+)
+
+var (
+	colorDust     = color.NRGBA{R: 150, G: 140, B: 130, A: 200}
+	colorStreak   = color.NRGBA{R: 255, G: 255, B: 255, A: 160}
+	burstColors   = []color.NRGBA{{R: 0, G: 120, B: 255, A: 255}, {R: 255, G: 200, B: 0, A: 255}, {R: 0, G: 200, B: 120, A: 255}}
+	pixelImage    = newPixelImage()
+	sparkleSprite = newSparkleSprite()
+)
+
+type Particle struct {
+	Pos
+	velocityX, velocityY float64
+	gravity              float64
+	life, maxLife        int
+	size                 float64
+	color                color.NRGBA
+	sprite               *ebiten.Image // drawn instead of a square when set
+	streak               bool          // stretched along its velocity
+	alive                bool
+}
+
+// Particles is a fixed pool so that emitting, updating and drawing never allocate during a frame
+type Particles struct {
+	pool [maxParticles]Particle
+	next int
+	op   ebiten.DrawImageOptions
+}
+
+func newPixelImage() *ebiten.Image {
+	img := ebiten.NewImage(1, 1)
+	img.Fill(color.White)
+	return img
+}
+
+// newSparkleSprite is a small white plus, tinted by the particle's color
+func newSparkleSprite() *ebiten.Image {
+	img := ebiten.NewImage(sparkleSize, sparkleSize)
+	for i := range sparkleSize {
+		img.Set(i, sparkleSize/2, color.White)
+		img.Set(sparkleSize/2, i, color.White)
+	}
+	return img
+}
+
+// emit reuses the next free particle, or the oldest one when the pool is full
+func (ps *Particles) emit(p Particle) {
+	slot := ps.next
+	for i := range maxParticles {
+		candidate := (ps.next + i) % maxParticles
+		if !ps.pool[candidate].alive {
+			slot = candidate
+			break
+		}
+	}
+	p.alive = true
+	p.maxLife = p.life
+	ps.pool[slot] = p
+	ps.next = (slot + 1) % maxParticles
+}
+
+func (ps *Particles) Update() {
+	for i := range ps.pool {
+		p := &ps.pool[i]
+		if !p.alive {
+			continue
+		}
+		p.life--
+		if p.life <= 0 {
+			*p = Particle{}
+			continue
+		}
+		p.velocityY += p.gravity
+		p.x += p.velocityX
+		p.y += p.velocityY
+	}
+}
+
+func (ps *Particles) Clear() {
+	for i := range ps.pool {
+		ps.pool[i] = Particle{}
+	}
+}
+
+// Draw fades every particle out over its lifetime
+func (ps *Particles) Draw(screen *ebiten.Image, camera *Camera) {
+	op := &ps.op
+	cameraGeoM := camera.GeoM()
+	for i := range ps.pool {
+		p := &ps.pool[i]
+		if !p.alive {
+			continue
+		}
+		op.GeoM.Reset()
+		op.ColorScale.Reset()
+		img := pixelImage
+		if p.sprite != nil {
+			img = p.sprite
+			bounds := img.Bounds()
+			op.GeoM.Translate(-float64(bounds.Dx())/2, -float64(bounds.Dy())/2)
+			op.GeoM.Scale(p.size/float64(bounds.Dx()), p.size/float64(bounds.Dx()))
+		} else if p.streak {
+			speed := math.Hypot(p.velocityX, p.velocityY)
+			op.GeoM.Scale(speed*streakLength, p.size)
+			op.GeoM.Rotate(math.Atan2(-p.velocityY, -p.velocityX))
+		} else {
+			op.GeoM.Translate(-0.5, -0.5)
+			op.GeoM.Scale(p.size, p.size)
+		}
+		op.GeoM.Translate(p.x, p.y)
+		op.GeoM.Concat(cameraGeoM)
+		op.ColorScale.ScaleWithColor(p.color)
+		op.ColorScale.ScaleAlpha(float32(p.life) / float32(p.maxLife))
+		screen.DrawImage(img, op)
+	}
+}
+
+// emitLandingDust kicks up dust on both sides of the player's feet
+func (ps *Particles) emitLandingDust(x, y float64) {
+	for i := range landingDustParticles {
+		dir := 1.0
+		if i%2 == 0 {
+			dir = -1
+		}
+		ps.emit(Particle{
+			Pos:       Pos{x: x, y: y},
+			velocityX: dir * (0.5 + rand.Float64()*1.5),
+			velocityY: -0.3 - rand.Float64()*0.7,
+			gravity:   0.03,
+			life:      20 + rand.Intn(15),
+			size:      2 + rand.Float64()*3,
+			color:     colorDust,
+		})
+	}
+}
+
+// emitGeocodeBurst celebrates a rooftop being geocoded
+func (ps *Particles) emitGeocodeBurst(x, y float64) {
+	for i := range geocodeBurstSize {
+		angle := float64(i) / geocodeBurstSize * 2 * math.Pi
+		speed := 1.5 + rand.Float64()*2
+		p := Particle{
+			Pos:       Pos{x: x, y: y},
+			velocityX: math.Cos(angle) * speed,
+			velocityY: math.Sin(angle) * speed,
+			gravity:   0.08,
+			life:      30 + rand.Intn(20),
+			size:      2 + rand.Float64()*2,
+			color:     burstColors[i%len(burstColors)],
+		}
+		if i%2 == 0 { // every other one sparkles
+			p.sprite = sparkleSprite
+			p.size = sparkleSize + rand.Float64()*3
+		}
+		ps.emit(p)
+	}
+}
+
+// emitFallStreak draws the player's fall off the bottom of the screen
+func (ps *Particles) emitFallStreak(x float64) {
+	for range fallStreakParticles {
+		ps.emit(Particle{
+			Pos:       Pos{x: x + rand.Float64()*playerSize, y: screenHeight - rand.Float64()*screenHeight/2},
+			velocityY: 10 + rand.Float64()*6,
+			life:      25 + rand.Intn(10),
+			size:      2,
+			color:     colorStreak,
+			streak:    true,
+		})
+	}
+}
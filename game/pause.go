@@ -0,0 +1,220 @@
+package game
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+type pauseScreen int
+
+const (
+	pauseMain pauseScreen = iota
+	pauseSettings
+	pauseControls
+	pauseAccessibility
+	numPauseScreens
+)
+
+const (
+	pauseIconSize     = 24
+	pauseIconMargin   = 6
+	pauseIconOffsetX  = 76 // left of the mute button
+	menuButtonWidth   = 240
+	menuButtonHeight  = 32
+	menuButtonSpacing = 8
+	pauseTitleHeight  = 50
+)
+
+var colorPauseBackdrop = color.RGBA{R: 0, G: 0, B: 0, A: 160}
+
+func (g *Game) initPauseMenu() {
+	g.pauseButton = NewButton(screenWidth-pauseIconOffsetX, 30, pauseIconSize, pauseIconSize, pauseIconMargin, g.Pause)
+	g.pauseButton.drawMoreStrategy = PauseIconStrategy{}
+
+	g.pauseMenus[pauseMain] = []*Button{
+		newMenuButton(translated("Resume"), g.Resume),
+		newMenuButton(translated("Restart"), func() {
+			g.Resume()
+			g.startOver()
+		}),
+		newMenuButton(translated("Settings"), func() { g.pauseScreen = pauseSettings }),
+		newMenuButton(translated("Quit to title"), func() {
+			g.Resume()
+			g.startOver()
+			g.gameStarted = false
+			bot = false
+		}),
+	}
+
+	settingsMenu := []*Button{
+		newMenuButton(func() string { return tr("Volume: %d%%", int(settings.Volume*100)) }, CycleVolume),
+		newMenuButton(translated("Controls"), func() { g.pauseScreen = pauseControls }),
+		newMenuButton(translated("Accessibility"), func() { g.pauseScreen = pauseAccessibility }),
+		newMenuButton(func() string { return tr("Jump timing help: %d frames", settings.CoyoteFrames) }, cycleJumpHelp),
+		newMenuButton(func() string {
+			return tr("Rooftop reveal: %s", tr(revealStyleNames[settings.RevealStyle]))
+		}, cycleRevealStyle),
+		NewToggleButton(0, 0, menuButtonWidth, menuButtonHeight, "Weather physics", &settings.WeatherPhysics, saveSettings),
+	}
+	if g.isMobile {
+		settingsMenu = append(settingsMenu, newMenuButton(func() string {
+			return tr("Touch controls: %s", tr(touchSchemeNames[settings.TouchScheme]))
+		}, cycleTouchScheme))
+	}
+	settingsMenu = append(settingsMenu,
+		newMenuButton(func() string { return tr("Language: %s", languageName(localizer.tag)) }, func() {
+			next := nextLanguage()
+			SetLanguage(next)
+			settings.Language = next.String()
+			saveSettings()
+		}),
+		newMenuButton(translated("Back"), func() { g.pauseScreen = pauseMain }),
+	)
+	g.pauseMenus[pauseSettings] = settingsMenu
+
+	g.pauseMenus[pauseAccessibility] = append(newAccessibilityToggles(menuButtonWidth, menuButtonHeight),
+		newMenuButton(translated("Back"), func() { g.pauseScreen = pauseSettings }),
+	)
+
+	g.rebinding = noAction
+	g.pauseMenus[pauseControls] = append(g.newRebindingButtons(),
+		newMenuButton(translated("Back"), func() { g.pauseScreen = pauseSettings }),
+	)
+	g.layoutPauseMenu()
+	g.stopPageHidden = onPageHidden(g.Pause)
+}
+
+func newMenuButton(textFunc func() string, btnFunc func()) *Button {
+	return NewLabelButton(0, 0, menuButtonWidth, menuButtonHeight, textFunc, btnFunc)
+}
+
+// layoutPauseMenu centers each screen's buttons under its title
+func (g *Game) layoutPauseMenu() {
+	g.pauseButton.SetCenter(screenWidth-pauseIconOffsetX, 30)
+	for _, buttons := range g.pauseMenus {
+		height := float64(len(buttons))*(menuButtonHeight+menuButtonSpacing) - menuButtonSpacing
+		top := (screenHeight+pauseTitleHeight-height)/2 + menuButtonHeight/2
+		for i, b := range buttons {
+			b.SetCenter(screenWidth/2, top+float64(i)*(menuButtonHeight+menuButtonSpacing))
+		}
+	}
+}
+
+// canPause is only while a solo run is going, a race can't wait for one player
+func (g *Game) canPause() bool {
+	return g.gameStarted && !g.gameOver && g.race == nil && g.spectator == nil
+}
+
+func (g *Game) Pause() {
+	if !g.canPause() || g.paused {
+		return
+	}
+	g.paused = true
+	g.pauseScreen = pauseMain
+	gamepads.resetFocus()
+}
+
+func (g *Game) Resume() {
+	g.paused = false
+}
+
+// updatePause handles the pause keys and the menu, it returns true while the game is paused so nothing else moves
+func (g *Game) updatePause() bool {
+	if g.rebinding != noAction {
+		g.updateRebinding()
+		return true
+	}
+	back := actionJustPressed(Pause)
+	if g.paused && (inpututil.IsKeyJustPressed(ebiten.KeyEscape) || gamepads.JustPressed(gamepadBack)) {
+		back = true // Escape always backs out of the menu, even when pausing is bound to something else
+	}
+	if back {
+		switch {
+		case !g.paused:
+			g.Pause()
+		case g.pauseScreen == pauseMain:
+			g.Resume()
+		default:
+			g.pauseScreen = pauseMain
+		}
+		return g.paused
+	}
+	if !g.paused {
+		if g.canPause() {
+			g.pauseButton.Update()
+		}
+		return g.paused
+	}
+	for _, b := range g.pauseMenus[g.pauseScreen] {
+		b.Update()
+		if b.isPressed {
+			return true // the button may have switched screens
+		}
+	}
+	gamepads.navigateMenu(g.pauseMenus[g.pauseScreen])
+	return true
+}
+
+// tapPause is for the mobile click handler, it reports whether the tap was for the pause icon or the menu
+func (g *Game) tapPause(x, y int) bool {
+	if g.rebinding != noAction {
+		g.rebinding = noAction // there's no key to wait for on a phone, a tap gives up like Escape does
+		return true
+	}
+	if !g.paused {
+		if g.canPause() && g.pauseButton.Overlaps(x, y) {
+			g.Pause()
+			return true
+		}
+		return false
+	}
+	for _, b := range g.pauseMenus[g.pauseScreen] {
+		if b.Overlaps(x, y) {
+			b.press()
+			break
+		}
+	}
+	return true // taps around the menu shouldn't make the player jump when it closes
+}
+
+func (g *Game) drawPause(screen *ebiten.Image) {
+	if !g.paused {
+		if g.canPause() {
+			g.pauseButton.Draw(screen)
+		}
+		return
+	}
+	vector.DrawFilledRect(screen, 0, 0, float32(screenWidth), screenHeight, colorPauseBackdrop, false)
+	font, _ := g.hud.fonts()
+	buttons := g.pauseMenus[g.pauseScreen]
+	titleY := buttons[0].y - pauseTitleHeight/2
+	switch g.pauseScreen {
+	case pauseMain:
+		font.Draw(screen, tr("Paused"), screenWidth/2, titleY, text.AlignCenter, 1)
+	case pauseSettings:
+		font.Draw(screen, tr("Settings"), screenWidth/2, titleY, text.AlignCenter, 1)
+	case pauseControls:
+		font.Draw(screen, tr("Controls"), screenWidth/2, titleY, text.AlignCenter, 1)
+	case pauseAccessibility:
+		font.Draw(screen, tr("Accessibility"), screenWidth/2, titleY, text.AlignCenter, 1)
+	}
+	for _, b := range buttons {
+		b.Draw(screen)
+	}
+}
+
+// PauseIconStrategy draws the two bars of a pause symbol, outlined so they show up against the sky and the buildings
+type PauseIconStrategy struct {
+}
+
+func (ps PauseIconStrategy) DrawButton(screen *ebiten.Image, b *Button) {
+	barWidth := float32(b.width) / 3
+	for _, x := range []float32{float32(b.x), float32(b.x+b.width) - barWidth} {
+		vector.DrawFilledRect(screen, x-1, float32(b.y)-1, barWidth+2, float32(b.height)+2, color.Black, false)
+		vector.DrawFilledRect(screen, x, float32(b.y), barWidth, float32(b.height), color.White, false)
+	}
+}
@@ -1,9 +1,7 @@
 package game
 
 import (
-	"image"
 	"image/color"
-	"log"
 	"math/rand"
 
 	"github.com/hajimehoshi/ebiten/v2"
@@ -11,143 +9,144 @@ import (
 	"github.com/smarty-archives/rooftop-geocoding-game/media"
 )
 
+const (
+	difficultyTierSize = 10
+	widthBandSize      = 25 // each band covers the widths up to halfway to the next one, so together they leave no gaps
+)
+
 type Platform struct {
 	Pos
 	image              *ebiten.Image
 	visitedImage       *ebiten.Image
+	windowsImage       *ebiten.Image // lit at night, made the first time it's needed
 	width              float64
+	index              int // how many buildings came before this one in the city
+	props              []roofProp
 	framesSinceVisited int
+	landingX           float64 // relative to the left of the building
 	visited            bool
 }
 
 func NewPlatform(x, y, width float64) *Platform {
-	img, err := media.Instance.LoadBuildingImage(getPlatformIndex(width))
-	if err != nil {
-		log.Fatal(err)
-	}
+	width = max(width, minBuildingWidth)
+	props := newRoofProps(width, int64(x)*31+int64(y)) // the same spot in the same city always gets the same props
+	height := buildingHeight(y)
 	return &Platform{
 		Pos:   *NewPos(x, y),
 		width: width,
-		image: img,
+		props: props,
+		image: newBuildingImage(media.Instance.GetBuildingPieces(false), int(width), height, props, false),
+		// made up front with the other image so landing on the building doesn't have to compose it
+		visitedImage: newBuildingImage(media.Instance.GetBuildingPieces(true), int(width), height, props, true),
 	}
 }
 
-func getPlatformIndex(width float64) int {
-	return (int(width) - 75) / 25 // 5 different widths from 75 to 175
-}
-
-func GenerateNewRandomPlatform(prevPlatform *Platform, score int) *Platform {
+// GenerateNewRandomPlatform only uses rng and the building's place in the city, never how the run is going, so that
+// the same seed always builds the same city
+func GenerateNewRandomPlatform(prevPlatform *Platform, rng *rand.Rand) *Platform {
 	x := prevPlatform.x
 	minX := x + prevPlatform.width + platformSpacing - 50
 	maxX := x + prevPlatform.width + platformSpacing + 50
-	randX := float64(rand.Intn(int(maxX)-int(minX))) + minX
+	randX := float64(rng.Intn(int(maxX)-int(minX))) + minX
 
 	y := prevPlatform.y
 	minY := max(y-maxYDeltaTop, maxPlatformHeight)
 	maxY := float64(screenHeight - minimumPlatformHeight)
-	randY := float64(rand.Intn(int(maxY)-int(minY))) + minY
+	randY := float64(rng.Intn(int(maxY)-int(minY))) + minY
 
-	randWidth := pickWidth(score, 175, 150, 125, 100, 75) // these numbers correspond to the widths of the building assets
-	return NewPlatform(randX, randY, randWidth)
+	index := prevPlatform.index + 1
+	p := NewPlatform(randX, randY, pickWidth(rng, index))
+	p.index = index
+	return p
 }
 
-func pickWidth(counter int, numbers ...float64) float64 {
-	var weights []float64
-	// As counter increases, shift probability towards later numbers
-	if counter > 60 {
-		weights = []float64{0.01, 0.02, 0.3, 0.3, 0.37}
-	} else if counter > 50 {
-		weights = []float64{0.05, 0.05, 0.3, 0.3, 0.3}
-	} else if counter > 40 {
-		weights = []float64{0.15, 0.15, 0.25, 0.25, 0.2}
-	} else if counter > 30 {
-		weights = []float64{0.3, 0.2, 0.2, 0.15, 0.15}
-	} else if counter > 20 {
-		weights = []float64{0.5, 0.3, 0.1, 0.05, 0.05}
-	} else if counter > 10 {
-		weights = []float64{0.37, 0.3, 0.3, 0.02, 0.01}
-	} else {
-		weights = []float64{0.5, 0.4, 0.1, 0, 0}
-	}
+// widthBands are the middles of the ranges of widths a building can have, from the widest to the narrowest
+var widthBands = []float64{175, 150, 125, 100, 75}
+
+// widthWeights has the chance of each width band for every difficulty tier, shifting towards narrower buildings
+// further into the city
+var widthWeights = [][]float64{
+	{0.5, 0.4, 0.1, 0, 0},
+	{0.37, 0.3, 0.3, 0.02, 0.01},
+	{0.5, 0.3, 0.1, 0.05, 0.05},
+	{0.3, 0.2, 0.2, 0.15, 0.15},
+	{0.15, 0.15, 0.25, 0.25, 0.2},
+	{0.05, 0.05, 0.3, 0.3, 0.3},
+	{0.01, 0.02, 0.3, 0.3, 0.37},
+}
 
-	// Pick a number based on weighted probabilities
-	r := rand.Float64()
+// difficultyTier goes up every difficultyTierSize buildings, counted by the score or by the buildings in the city
+func difficultyTier(score int) int {
+	return min(max(score-1, 0)/difficultyTierSize, len(widthWeights)-1)
+}
+
+// pickWidth picks a band by the tier's weights, then any width inside it
+func pickWidth(rng *rand.Rand, index int) float64 {
+	weights := widthWeights[difficultyTier(index)]
+	band := len(widthBands) - 1
+	r := rng.Float64()
 	sum := 0.0
 	for i, w := range weights {
 		sum += w
 		if r < sum {
-			return numbers[i]
+			band = i
+			break
 		}
 	}
-	return numbers[len(numbers)-1] // Fallback should never happen
+	return widthBands[band] + (rng.Float64()-0.5)*widthBandSize
 }
 
 func giveOrTake(num, delta float64) float64 {
 	return float64(rand.Intn(int(num+delta)-int(num-delta))) + num - delta
 }
 
-func (p *Platform) Draw(screen *ebiten.Image, cameraX float64) {
-	x := p.x - cameraX
-	scaleX, scaleY := 1.0, 1.0
-
-	imgWidth := p.image.Bounds().Dx()
-	imgHeight := p.image.Bounds().Dy()
-
-	const revealFrames = 10
-	const minAlpha = 0.9
-	const maxAlpha = 1.0
-	const buildingFeature = true
-
-	if p.framesSinceVisited < revealFrames && buildingFeature {
-		// unvisited image
-		unvisitedCoor := &ebiten.DrawImageOptions{}
-		unvisitedCoor.GeoM.Scale(scaleX, scaleY)
-		unvisitedCoor.GeoM.Translate(x, p.y)
-
-		screen.DrawImage(p.image, unvisitedCoor)
-		if !p.visited {
-			return
-		}
-		// progressively reveal image
-		progress := float64(p.framesSinceVisited) / float64(revealFrames)
-
-		for y := 0; y < imgHeight; y++ {
-			sliceProgress := float64(y) / float64(imgHeight)
-			alphaProgress := progress - sliceProgress
-
-			if alphaProgress <= 0 {
-				continue // not revealed yet
-			}
-			if alphaProgress > 1 {
-				alphaProgress = 1
-			}
-
-			alpha := minAlpha + alphaProgress*(maxAlpha-minAlpha)
-			slice := p.visitedImage.SubImage(image.Rect(0, y, imgWidth, y+1)).(*ebiten.Image)
-
-			revealCoor := &ebiten.DrawImageOptions{}
-			revealCoor.GeoM.Translate(x, p.y+float64(y))
-			revealCoor.ColorScale.Scale(1, 1, 1, float32(alpha))
-
-			screen.DrawImage(slice, revealCoor)
+func (p *Platform) Draw(screen *ebiten.Image, camera *Camera, timeOfDay TimeOfDay) {
+	if !p.visited {
+		p.drawImage(screen, p.image, camera, timeOfDay)
+		return
+	}
+	if p.framesSinceVisited < settings.RevealStyle.frames() && !settings.ReducedMotion {
+		p.drawReveal(screen, camera, timeOfDay)
+		return
+	}
+	p.drawImage(screen, p.visitedImage, camera, timeOfDay)
+	if timeOfDay.windows > 0.01 {
+		windowsCoor := &ebiten.DrawImageOptions{}
+		windowsCoor.GeoM.Translate(p.x, p.y-roofHeadroom)
+		windowsCoor.GeoM.Concat(camera.GeoM())
+		windowsCoor.ColorScale.ScaleAlpha(timeOfDay.windows)
+		if p.windowsImage == nil {
+			p.windowsImage = newWindowsImage(p.visitedImage)
 		}
-	} else {
-		visitedCoor := &ebiten.DrawImageOptions{}
-		visitedCoor.GeoM.Scale(scaleX, scaleY)
-		visitedCoor.GeoM.Translate(x, p.y)
-		screen.DrawImage(p.visitedImage, visitedCoor)
+		screen.DrawImage(p.windowsImage, windowsCoor)
 	}
 }
 
-func (p *Platform) drawHitBox(screen *ebiten.Image, cameraX float64) {
-	vector.DrawFilledRect(screen, float32(p.x-cameraX), float32(p.y), float32(p.width), float32(maxPlatformHeight), color.RGBA{R: 255}, false)
+func (p *Platform) drawImage(screen, img *ebiten.Image, camera *Camera, timeOfDay TimeOfDay) {
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(p.x, p.y-roofHeadroom)
+	op.GeoM.Concat(camera.GeoM())
+	op.ColorScale.ScaleWithColorScale(timeOfDay.ColorScale())
+	op.ColorScale.ScaleWithColorScale(buildingTint(p.visited))
+	screen.DrawImage(img, op)
+}
+
+func (p *Platform) drawHitBox(screen *ebiten.Image, camera *Camera) {
+	x, y := camera.ToScreen(p.x, p.y)
+	vector.DrawFilledRect(screen, float32(x), float32(y), float32(p.width), float32(maxPlatformHeight), color.RGBA{R: 255}, false)
 }
 
-func (p *Platform) Visit() {
+// Visit marks the building as geocoded, landingX is where the player landed and is where some reveals start from
+func (p *Platform) Visit(landingX float64) {
 	p.visited = true
-	newImage, err := media.Instance.LoadVisitedBuildingImage(getPlatformIndex(p.width))
-	if err != nil {
-		log.Fatal(err)
+	p.landingX = landingX - p.x
+}
+
+// Deallocate frees the building images once the building has scrolled away
+func (p *Platform) Deallocate() {
+	p.image.Deallocate()
+	p.visitedImage.Deallocate()
+	if p.windowsImage != nil {
+		p.windowsImage.Deallocate()
 	}
-	p.visitedImage = newImage
 }
@@ -14,6 +14,8 @@ const (
 	startingJumpForce          = -12
 	startingPlayerAcceleration = 0.2
 	startingMaxPlayerSpeed     = 4
+	startingTraction           = 0.2
+	spawnFramesInAir           = 1000 // the player drops in from above the screen, that's no time to be jumping
 )
 
 type HitBox struct {
@@ -27,8 +29,11 @@ type Player struct {
 	velocityX float64
 	velocityY float64
 	isJumping bool
-	animation PlayerAnimationState
-	image     *ebiten.Image
+	// framesInAir counts up from the last landing for coyote time, jumpBufferLeft counts down a jump pressed too early
+	framesInAir    int
+	jumpBufferLeft int
+	animation      PlayerAnimationState
+	image          *ebiten.Image
 }
 
 func NewPlayer() *Player {
@@ -38,14 +43,17 @@ func NewPlayer() *Player {
 }
 
 func (p *Player) ResetPlayer() {
-	p.x = screenWidth/2 - (playerSize / 2)
+	p.x = startingPlayerX
 	p.y = 0
 	p.velocityX = 0
 	p.velocityY = 0
 	p.isJumping = false
+	p.framesInAir = spawnFramesInAir
+	p.jumpBufferLeft = 0
 	p.jumpForce = startingJumpForce
 	p.playerAcceleration = startingPlayerAcceleration
 	p.maxPlayerSpeed = startingMaxPlayerSpeed
+	p.traction = startingTraction
 	p.width = 20
 	p.height = playerSize
 	image, err := media.Instance.LoadRunningImage(0)
@@ -59,6 +67,8 @@ func (p *Player) ResetPlayer() {
 func (p *Player) Jump() {
 	p.velocityY = p.GetJumpForce()
 	p.isJumping = true
+	p.jumpBufferLeft = 0
+	sounds.Play(media.SoundJump)
 }
 
 func (p *Player) Accelerate(dir float64) {
@@ -81,25 +91,32 @@ func (p *Player) SetStats(jumpForce, playerSpeed, maxPlayerSpeed float64) {
 	p.SetMaxPlayerSpeed(maxPlayerSpeed)
 }
 
-func (p *Player) Draw(screen *ebiten.Image, cameraX float64) {
+func (p *Player) Draw(screen *ebiten.Image, camera *Camera) {
 	//if debugMode {
-	//	p.DrawHitBox(screen, cameraX)
+	//	p.DrawHitBox(screen, camera)
 	//}
+	p.drawWithAlpha(screen, camera, 1)
+}
+
+func (p *Player) drawWithAlpha(screen *ebiten.Image, camera *Camera, alpha float32) {
 	playerCoor := &ebiten.DrawImageOptions{}
 	scaleX := playerSize / float64(p.image.Bounds().Dx())
 	scaleY := scaleX
-	x := p.x - cameraX
+	x := p.x
 	if p.velocityX < 0 {
 		scaleX = -scaleX
 		x += playerSize
 	}
 	playerCoor.GeoM.Scale(scaleX, scaleY)
 	playerCoor.GeoM.Translate(x, p.y)
+	playerCoor.GeoM.Concat(camera.GeoM())
+	playerCoor.ColorScale.ScaleAlpha(alpha)
 	screen.DrawImage(p.image, playerCoor)
 }
 
-func (p *Player) DrawHitBox(screen *ebiten.Image, cameraX float64) {
-	vector.DrawFilledRect(screen, float32(p.x-cameraX+p.width/2), float32(p.y), float32(p.width), float32(p.height), color.RGBA{R: 255}, false)
+func (p *Player) DrawHitBox(screen *ebiten.Image, camera *Camera) {
+	x, y := camera.ToScreen(p.x+p.width/2, p.y)
+	vector.DrawFilledRect(screen, float32(x), float32(y), float32(p.width), float32(p.height), color.RGBA{R: 255}, false)
 }
 
 func (p *Player) cycleImage() {
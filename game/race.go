@@ -0,0 +1,219 @@
+package game
+
+import (
+	"strconv"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/smarty-archives/rooftop-geocoding-game/race"
+)
+
+const (
+	ghostAlpha        = 0.4
+	ghostNameOffset   = 10 // names float just above the ghost's head
+	raceStateInterval = 2  // send where the player is every this many frames
+)
+
+// Race keeps track of the other runners while everyone plays the same seeded city
+type Race struct {
+	client   *race.Client
+	id       int
+	hostID   int
+	room     string
+	players  []race.PlayerInfo
+	ghosts   map[int]*Ghost
+	results  []race.Result
+	racing   bool
+	sentFell bool
+	errMsg   string
+	frames   int
+}
+
+// Ghost is another runner in the race, drawn as a translucent Player
+type Ghost struct {
+	*Player
+	name  string
+	fell  bool
+	score int
+}
+
+func NewGhost(name string) *Ghost {
+	return &Ghost{Player: NewPlayer(), name: name}
+}
+
+func (gh *Ghost) setState(state *race.RunnerState) {
+	gh.x = state.X
+	gh.y = state.Y
+	gh.velocityX = state.VelocityX
+	gh.isJumping = state.Jumping
+	gh.score = state.Score
+}
+
+// JoinRace connects to the race server and waits in the room's lobby until the host starts the race
+func (g *Game) JoinRace(url, room, name string) error {
+	client, err := race.Dial(url)
+	if err != nil {
+		return err
+	}
+	client.Send(race.Message{Type: race.TypeJoin, Room: room, Name: name})
+	g.race = &Race{client: client, room: room, ghosts: map[int]*Ghost{}}
+	bot = false
+	return nil
+}
+
+func (r *Race) isHost() bool {
+	return r.id != 0 && r.id == r.hostID
+}
+
+func (r *Race) requestStart() {
+	if r.isHost() && !r.racing {
+		r.client.Send(race.Message{Type: race.TypeStart})
+	}
+}
+
+func (r *Race) playerName(id int) string {
+	for _, p := range r.players {
+		if p.ID == id {
+			return p.Name
+		}
+	}
+	return tr("Runner")
+}
+
+func (g *Game) handleRace() {
+	r := g.race
+	for _, msg := range r.client.Poll() {
+		switch msg.Type {
+		case race.TypeWelcome:
+			r.id = msg.ID
+		case race.TypeLobby:
+			r.players = msg.Players
+			r.hostID = msg.HostID
+		case race.TypeStart:
+			r.results = nil
+			r.racing = true
+			r.sentFell = false
+			r.errMsg = ""
+			r.ghosts = map[int]*Ghost{}
+			for _, p := range r.players {
+				if p.ID != r.id {
+					r.ghosts[p.ID] = NewGhost(p.Name)
+				}
+			}
+			g.startOverWithSeed(msg.Seed)
+			g.gameStarted = true
+		case race.TypeState:
+			if ghost, ok := r.ghosts[msg.ID]; ok && msg.State != nil {
+				ghost.setState(msg.State)
+			}
+		case race.TypeFell:
+			if ghost, ok := r.ghosts[msg.ID]; ok {
+				ghost.fell = true
+				ghost.score = msg.Score
+			}
+		case race.TypeLeft:
+			delete(r.ghosts, msg.ID)
+		case race.TypeResults:
+			r.results = msg.Results
+			r.racing = false
+		case race.TypeError:
+			r.errMsg = msg.Error
+		}
+	}
+	if r.client.Closed() && r.errMsg == "" {
+		r.errMsg = "lost connection to the race server"
+	}
+
+	for _, ghost := range r.ghosts {
+		ghost.cycleImage()
+	}
+	if !r.racing || !g.gameStarted {
+		return
+	}
+	r.frames++
+	if g.gameOver {
+		if !r.sentFell {
+			r.client.Send(race.Message{Type: race.TypeFell, Score: g.score})
+			r.sentFell = true
+		}
+	} else if r.frames%raceStateInterval == 0 {
+		r.client.Send(race.Message{Type: race.TypeState, State: g.runnerState()})
+	}
+}
+
+func (g *Game) runnerState() *race.RunnerState {
+	return &race.RunnerState{
+		X:         g.player.x,
+		Y:         g.player.y,
+		VelocityX: g.player.velocityX,
+		Jumping:   g.player.isJumping,
+		Score:     g.score,
+	}
+}
+
+func (g *Game) drawGhosts(screen *ebiten.Image) {
+	for _, ghost := range g.race.ghosts {
+		if !ghost.fell {
+			ghost.drawWithAlpha(screen, g.camera, ghostAlpha)
+			nameX, nameY := g.camera.ToScreen(ghost.GetCenterX(), ghost.y)
+			g.drawTextCenteredOn(screen, ghost.name, int(nameX), int(nameY)-ghostNameOffset)
+		}
+	}
+}
+
+// drawRaceLobby replaces the start button while waiting in a room
+func (g *Game) drawRaceLobby(screen *ebiten.Image) {
+	r := g.race
+	lines := []string{
+		tr("Room %s: %d/%d runners", r.room, len(r.players), race.MaxPlayers),
+	}
+	for _, p := range r.players {
+		lines = append(lines, p.Name)
+	}
+	lines = append(lines, g.raceWaitingText())
+	g.drawLines(screen, lines, screenHeight/2+30)
+}
+
+// drawRaceStandings shows who is still running, or the results once the last runner fell
+func (g *Game) drawRaceStandings(screen *ebiten.Image) {
+	r := g.race
+	var lines []string
+	if r.results != nil {
+		lines = append(lines, tr("Race results"))
+		for i, result := range r.results {
+			lines = append(lines, strconv.Itoa(i+1)+". "+result.Name+": "+formatNumber(result.Score))
+		}
+		lines = append(lines, g.raceWaitingText())
+	} else {
+		running := 0
+		for _, ghost := range r.ghosts {
+			if !ghost.fell {
+				running++
+			}
+		}
+		lines = append(lines, tr("Waiting for %d runner(s) to fall...", running))
+	}
+	g.drawLines(screen, lines, 120)
+}
+
+func (g *Game) raceWaitingText() string {
+	r := g.race
+	if r.errMsg != "" {
+		return r.errMsg
+	}
+	if !r.isHost() {
+		return tr("Waiting for %s to start the race", r.playerName(r.hostID))
+	}
+	if len(r.players) < race.MinPlayers {
+		return tr("Waiting for more runners to join")
+	}
+	if g.isMobile {
+		return tr("Tap to start the race")
+	}
+	return tr("Press enter to start the race")
+}
+
+func (g *Game) drawLines(screen *ebiten.Image, lines []string, top int) {
+	for i, line := range lines {
+		g.drawTextCenteredOn(screen, line, int(screenWidth)/2, top+i*20)
+	}
+}
@@ -0,0 +1,178 @@
+package game
+
+import (
+	"image/color"
+	"log"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+type RevealStyle int
+
+const (
+	RevealSweep    RevealStyle = iota // top to bottom, like it always was
+	RevealRadial                      // a pulse spreading from where the player landed
+	RevealScanline                    // interlaced rows with a bright scan line
+	RevealPinDrop                     // a map pin drops on the landing point, then the pulse spreads
+	numRevealStyles
+)
+
+var revealStyleNames = [numRevealStyles]string{
+	RevealSweep:    "Sweep",
+	RevealRadial:   "Radial",
+	RevealScanline: "Scan lines",
+	RevealPinDrop:  "Pin drop",
+}
+
+const (
+	revealMinAlpha  = 0.9
+	pinDropPart     = 0.4 // fraction of the pin drop reveal spent dropping the pin
+	pinRadius       = 6
+	pinHeight       = 14
+	pinDropDistance = 60
+)
+
+var (
+	revealShader *ebiten.Shader
+	colorPin     = color.RGBA{R: 230, G: 40, B: 50, A: 255}
+)
+
+// revealShaderSource blends from the unvisited building (image 0) to the visited one (image 1) in a single draw
+const revealShaderSource = `//kage:unit pixels
+
+package main
+
+var Progress float
+var MinAlpha float
+var PinDropPart float
+var BeforeTint vec4
+var AfterTint vec4
+var Style int
+var Origin vec2
+
+func over(before, after vec4, alpha float) vec4 {
+	return after*alpha + before*(1-after.a*alpha)
+}
+
+func reveal(before, after vec4, edge float) vec4 {
+	if edge <= 0 {
+		return before
+	}
+	alpha := MinAlpha + clamp(edge, 0, 1)*(1-MinAlpha)
+	return over(before, after, alpha)
+}
+
+func radial(pos, size vec2, progress float) float {
+	return progress*1.2 - distance(pos, Origin)/length(size)
+}
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	size := imageSrc0Size()
+	pos := srcPos - imageSrc0Origin()
+	before := imageSrc0At(srcPos) * BeforeTint
+	after := imageSrc1At(srcPos) * AfterTint
+
+	result := before
+	if Style == 1 {
+		edge := radial(pos, size, Progress)
+		result = reveal(before, after, edge*5)
+		if edge > 0 && edge < 0.04 {
+			result += vec4(0.25) * after.a
+		}
+	} else if Style == 2 {
+		row := floor(pos.y / 2)
+		edge := Progress*1.3 - pos.y/size.y - mod(row, 2)*0.15
+		result = reveal(before, after, edge)
+		if edge > 0 && edge < 0.03 {
+			result += vec4(0.35) * after.a
+		}
+	} else if Style == 3 {
+		progress := (Progress - PinDropPart) / (1 - PinDropPart)
+		result = reveal(before, after, radial(pos, size, progress)*5)
+	} else {
+		result = reveal(before, after, Progress-pos.y/size.y)
+	}
+	return result * color
+}
+`
+
+// cycleRevealStyle switches to the next way rooftops light up when they're geocoded
+func cycleRevealStyle() {
+	settings.RevealStyle = (settings.RevealStyle + 1) % numRevealStyles
+	saveSettings()
+}
+
+func (s RevealStyle) frames() int {
+	switch s {
+	case RevealRadial:
+		return 20
+	case RevealScanline:
+		return 16
+	case RevealPinDrop:
+		return 30
+	}
+	return 10
+}
+
+func getRevealShader() *ebiten.Shader {
+	if revealShader != nil {
+		return revealShader
+	}
+	shader, err := ebiten.NewShader([]byte(revealShaderSource))
+	if err != nil {
+		log.Fatal(err)
+	}
+	revealShader = shader
+	return revealShader
+}
+
+// drawReveal blends the building from unvisited to visited with the reveal shader
+func (p *Platform) drawReveal(screen *ebiten.Image, camera *Camera, timeOfDay TimeOfDay) {
+	bounds := p.image.Bounds()
+	if bounds != p.visitedImage.Bounds() { // the shader needs both images to be the same size
+		p.drawImage(screen, p.visitedImage, camera, timeOfDay)
+		return
+	}
+	progress := float32(p.framesSinceVisited) / float32(settings.RevealStyle.frames())
+	op := &ebiten.DrawRectShaderOptions{}
+	op.GeoM.Translate(p.x, p.y-roofHeadroom)
+	op.GeoM.Concat(camera.GeoM())
+	op.ColorScale = timeOfDay.ColorScale()
+	op.Images[0] = p.image
+	op.Images[1] = p.visitedImage
+	op.Uniforms = map[string]any{
+		"Progress":    progress,
+		"MinAlpha":    float32(revealMinAlpha),
+		"PinDropPart": float32(pinDropPart),
+		"Style":       int(settings.RevealStyle),
+		"Origin":      []float32{float32(p.landingX), roofHeadroom},
+		"BeforeTint":  tintUniform(buildingTint(false)),
+		"AfterTint":   tintUniform(buildingTint(true)),
+	}
+	screen.DrawRectShader(bounds.Dx(), bounds.Dy(), getRevealShader(), op)
+
+	if settings.RevealStyle == RevealPinDrop {
+		drop := min(progress/pinDropPart, 1)
+		pinX, pinY := camera.ToScreen(p.x+p.landingX, p.y)
+		alpha := min(2-2*progress, 1) // fades out over the second half
+		drawPin(screen, float32(pinX), float32(pinY)-pinDropDistance*(1-drop), alpha)
+	}
+}
+
+func tintUniform(tint ebiten.ColorScale) []float32 {
+	return []float32{tint.R(), tint.G(), tint.B(), tint.A()}
+}
+
+// drawPin draws a map pin with its point at x, y
+func drawPin(screen *ebiten.Image, x, y, alpha float32) {
+	pinColor := color.NRGBA{R: colorPin.R, G: colorPin.G, B: colorPin.B, A: uint8(255 * alpha)}
+	headY := y - pinHeight
+	var path vector.Path
+	path.MoveTo(x-pinRadius*0.8, headY+pinRadius*0.5)
+	path.LineTo(x+pinRadius*0.8, headY+pinRadius*0.5)
+	path.LineTo(x, y)
+	path.Close()
+	fillPath(screen, &path, pinColor)
+	vector.DrawFilledCircle(screen, x, headY, pinRadius, pinColor, true)
+}
@@ -0,0 +1,84 @@
+package game
+
+import (
+	"encoding/json"
+	"log"
+)
+
+const (
+	settingsKey           = "settings"
+	defaultJumpHelpFrames = 6 // a tenth of a second
+)
+
+// jumpHelpSteps are what the jump timing setting goes through, in frames for both coyote time and the jump buffer
+var jumpHelpSteps = []int{0, 3, defaultJumpHelpFrames, 10}
+
+// Settings are the player's choices, saved in the browser or the user's config directory between visits
+type Settings struct {
+	Muted         bool        `json:"muted"`
+	Volume        float64     `json:"volume"`
+	Language      string      `json:"language,omitempty"` // empty follows the browser or OS
+	ReducedMotion bool        `json:"reducedMotion"`      // no parallax clouds, rooftop reveals or screen shake
+	HighContrast  bool        `json:"highContrast"`       // HUD and geocodes in white with a thick black outline
+	Colorblind    bool        `json:"colorblind"`         // visited and unvisited rooftops tinted blue and orange
+	SlowSpeed     bool        `json:"slowSpeed"`
+	TouchScheme   TouchScheme `json:"touchScheme"`
+	RevealStyle   RevealStyle `json:"revealStyle"`
+	// WeatherPhysics lets wind push the player and rain make roofs slippery, without it the weather is only drawn
+	WeatherPhysics bool `json:"weatherPhysics"`
+	// CoyoteFrames is how long after running off a roof a jump still counts, JumpBufferFrames is how long before
+	// landing a jump gets held on to
+	CoyoteFrames     int `json:"coyoteFrames"`
+	JumpBufferFrames int `json:"jumpBufferFrames"`
+}
+
+var settings = loadSettings()
+
+// loadSettings starts from what the browser says the player prefers, then applies anything they chose before
+func loadSettings() Settings {
+	s := Settings{
+		Volume:           1,
+		WeatherPhysics:   true,
+		CoyoteFrames:     defaultJumpHelpFrames,
+		JumpBufferFrames: defaultJumpHelpFrames,
+		ReducedMotion:    prefersReducedMotion(),
+		HighContrast:     prefersHighContrast(),
+	}
+	if value, ok := loadValue(settingsKey); ok {
+		if err := json.Unmarshal([]byte(value), &s); err != nil {
+			log.Println("Ignoring the saved settings:", err)
+		}
+	}
+	// a value saved by a newer build, or edited by hand, could be past the end of the lists of names
+	if s.RevealStyle < 0 || s.RevealStyle >= numRevealStyles {
+		s.RevealStyle = RevealSweep
+	}
+	if s.TouchScheme < 0 || s.TouchScheme >= numTouchSchemes {
+		s.TouchScheme = TouchOneTap
+	}
+	return s
+}
+
+// cycleJumpHelp gives the player more time to jump around the edges of roofs, going back to none after the most
+func cycleJumpHelp() {
+	next := jumpHelpSteps[0]
+	for _, frames := range jumpHelpSteps {
+		if frames > settings.CoyoteFrames {
+			next = frames
+			break
+		}
+	}
+	settings.CoyoteFrames = next
+	settings.JumpBufferFrames = next
+	saveSettings()
+}
+
+func saveSettings() {
+	data, err := json.Marshal(settings)
+	if err != nil {
+		log.Println("Not saving the settings:", err)
+		return
+	}
+	saveValue(settingsKey, string(data))
+	applySettings()
+}
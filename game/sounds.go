@@ -0,0 +1,118 @@
+package game
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"log"
+	"math/rand"
+
+	"github.com/hajimehoshi/ebiten/v2/audio"
+	"github.com/hajimehoshi/ebiten/v2/audio/wav"
+	"github.com/smarty-archives/rooftop-geocoding-game/media"
+)
+
+const (
+	playersPerSample = 2 // enough for a sound to overlap itself, like landing right after a jump
+	bytesPerFrame    = 4 // 16 bit stereo
+	effectsVolume    = .6
+)
+
+// soundPitches are the speeds every recording is also played at, so the same recording doesn't sound the same twice
+// in a row. Sounds that mean something specific, like a new best score, always sound the same
+var soundPitches = map[media.Sound][]float64{
+	media.SoundJump:    {.92, 1, 1.08},
+	media.SoundLand:    {.9, .96, 1, 1.05},
+	media.SoundGeocode: {.97, 1, 1.03},
+}
+
+// SoundEffects plays the sound for a game event from a pool of players made up front, so nothing is decoded mid-run
+type SoundEffects struct {
+	pools map[media.Sound][]*audio.Player
+}
+
+var sounds *SoundEffects
+
+func NewSoundEffects(context *audio.Context) *SoundEffects {
+	s := &SoundEffects{pools: map[media.Sound][]*audio.Player{}}
+	for _, sound := range []media.Sound{media.SoundJump, media.SoundLand, media.SoundGeocode, media.SoundFall, media.SoundClick, media.SoundMilestone} {
+		for _, data := range media.Instance.GetSoundVariants(sound) {
+			pcm, err := decodeSound(data)
+			if err != nil {
+				log.Println("Skipping a sound effect:", err)
+				continue
+			}
+			for _, pitch := range pitchesFor(sound) {
+				pitched := pitchShift(pcm, pitch)
+				for range playersPerSample {
+					s.pools[sound] = append(s.pools[sound], context.NewPlayerFromBytes(pitched))
+				}
+			}
+		}
+	}
+	return s
+}
+
+func pitchesFor(sound media.Sound) []float64 {
+	if pitches, ok := soundPitches[sound]; ok {
+		return pitches
+	}
+	return []float64{1}
+}
+
+func decodeSound(data []byte) ([]byte, error) {
+	stream, err := wav.DecodeWithSampleRate(sampleRate, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(stream)
+}
+
+// Play starts a random recording of the sound at a random pitch on a player that isn't busy, or cuts one short if
+// they all are
+func (s *SoundEffects) Play(sound media.Sound) {
+	if s == nil || isMuted {
+		return
+	}
+	pool := s.pools[sound]
+	if len(pool) == 0 {
+		return
+	}
+	start := rand.Intn(len(pool))
+	player := pool[start]
+	for i := range pool {
+		if candidate := pool[(start+i)%len(pool)]; !candidate.IsPlaying() {
+			player = candidate
+			break
+		}
+	}
+	if err := player.Rewind(); err != nil {
+		log.Println(err)
+		return
+	}
+	player.SetVolume(settings.Volume * effectsVolume)
+	player.Play()
+}
+
+// pitchShift resamples 16 bit stereo PCM so it plays faster and higher when ratio is over 1, and slower and lower under 1
+func pitchShift(pcm []byte, ratio float64) []byte {
+	if ratio == 1 {
+		return pcm
+	}
+	frames := len(pcm) / bytesPerFrame
+	shifted := make([]byte, int(float64(frames)/ratio)*bytesPerFrame)
+	sample := func(frame, channel int) float64 {
+		return float64(int16(binary.LittleEndian.Uint16(pcm[frame*bytesPerFrame+channel*2:])))
+	}
+	for i := range len(shifted) / bytesPerFrame {
+		position := float64(i) * ratio
+		frame := int(position)
+		next := min(frame+1, frames-1)
+		weight := position - float64(frame)
+		for channel := range 2 {
+			value := sample(frame, channel)*(1-weight) + sample(next, channel)*weight
+			binary.LittleEndian.PutUint16(shifted[i*bytesPerFrame+channel*2:], uint16(int16(value)))
+		}
+	}
+	return shifted
+}
@@ -0,0 +1,210 @@
+package game
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"strconv"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/smarty-archives/rooftop-geocoding-game/race"
+	"rsc.io/qr"
+)
+
+const (
+	spectatorIdleFrames  = 180 // how long a finished run stays on screen before the bot takes over
+	spectatorStallFrames = 300 // a followed run that stops sending for this long is given up on
+	spectatorTextScale   = 2
+	sidebarWidth         = 170
+	qrModuleSize         = 3
+	qrQuietZone          = 2
+)
+
+var colorSidebar = color.NRGBA{R: 255, G: 255, B: 255, A: 200}
+
+// Spectator follows the runs published from a booth on a big screen without taking any input
+type Spectator struct {
+	client          *race.Client
+	qrCode          *ebiten.Image
+	leaderboard     []race.Result
+	runnerName      string
+	following       bool
+	idleFrames      int
+	framesSinceSeen int
+}
+
+// Spectate shows the runs published to the booth, with the bot playing whenever nobody is
+func (g *Game) Spectate(url, booth, joinURL string) error {
+	client, err := race.Dial(url)
+	if err != nil {
+		return err
+	}
+	client.Send(race.Message{Type: race.TypeWatch, Room: booth})
+	qrCode, err := newQRCodeImage(joinURL)
+	if err != nil {
+		return err
+	}
+	g.spectator = &Spectator{client: client, qrCode: qrCode}
+	g.startSpectatorBot()
+	return nil
+}
+
+func (g *Game) updateSpectator() {
+	s := g.spectator
+	g.handleBackgroundLayers()
+	g.handleBackgroundClouds()
+	g.handleTimeOfDay()
+	for _, msg := range s.client.Poll() {
+		switch msg.Type {
+		case race.TypeRun:
+			s.following = true
+			s.runnerName = msg.Name
+			s.framesSinceSeen = 0
+			bot = false
+			g.startOverWithSeed(msg.Seed)
+		case race.TypeState:
+			if s.following && msg.State != nil && !g.gameOver {
+				g.followRunner(msg.State)
+				s.framesSinceSeen = 0
+			}
+		case race.TypeFell:
+			if s.following {
+				g.stopFollowing()
+			}
+		case race.TypeLeaderboard:
+			s.leaderboard = msg.Results
+		}
+	}
+	if s.following {
+		s.framesSinceSeen++
+		if s.framesSinceSeen > spectatorStallFrames {
+			g.stopFollowing()
+		}
+	} else {
+		g.updateSpectatorBot()
+	}
+	g.handleGeocodes()
+	g.particles.Update()
+	g.camera.Update()
+}
+
+func (g *Game) stopFollowing() {
+	g.spectator.following = false
+	g.spectator.idleFrames = spectatorIdleFrames
+	g.gameOver = true
+}
+
+// followRunner replays one frame of the booth runner's game in the same order Update does it
+func (g *Game) followRunner(state *race.RunnerState) {
+	g.handlePlatforms()
+	g.handleWeather()
+	if g.player.isJumping && !state.Jumping {
+		g.particles.emitLandingDust(g.player.GetCenterX(), state.Y+playerSize)
+		g.handleHardLanding(state.Y - g.player.y) // the state has no vertical speed, but this is close enough
+	}
+	g.player.x = state.X
+	g.player.y = state.Y
+	g.player.velocityX = state.VelocityX
+	g.player.isJumping = state.Jumping
+	g.player.cycleImage()
+	if state.Score > g.score {
+		if p := g.platformUnderPlayer(); p != nil && !p.visited {
+			p.Visit(g.player.GetCenterX())
+			g.addGeocode()
+		}
+		g.score = state.Score
+	}
+	g.handleCameraMovement()
+}
+
+func (g *Game) platformUnderPlayer() *Platform {
+	for _, p := range g.platforms {
+		if g.player.RightX() > p.x && g.player.LeftX() < p.x+p.width && math.Abs(g.player.y+playerSize-p.y) < 1 {
+			return p
+		}
+	}
+	return nil
+}
+
+func (g *Game) startSpectatorBot() {
+	bot = true
+	g.spectator.runnerName = botName
+	g.startOver()
+	g.gameStarted = true
+}
+
+// updateSpectatorBot is the bot half of Update, restarting on its own after a game over
+func (g *Game) updateSpectatorBot() {
+	s := g.spectator
+	if g.gameOver {
+		if s.idleFrames > 0 {
+			s.idleFrames--
+			return
+		}
+		g.startSpectatorBot()
+		return
+	}
+	g.handlePlatforms()
+	g.handleWeather()
+	g.checkGameOver()
+	if g.gameOver {
+		s.idleFrames = spectatorIdleFrames
+		return
+	}
+	prevLeft := g.player.LeftX()
+	prevRight := g.player.RightX()
+	g.handlePlayer()
+	g.handlePlatformCollision(prevLeft, prevRight)
+	g.handleScreenBounds()
+	g.handleCameraMovement()
+}
+
+// drawSpectatorOverlay is the big screen HUD: a large score, the leaderboard and a QR code to play
+func (g *Game) drawSpectatorOverlay(screen *ebiten.Image) {
+	s := g.spectator
+	g.drawTextScaled(screen, tr("Rooftops Geocoded: %d", g.score), 10, 30, spectatorTextScale)
+	g.drawTextScaled(screen, tr("Now playing: %s", s.runnerName), 10, 60, 1)
+
+	sidebarX := float32(screenWidth - sidebarWidth)
+	vector.DrawFilledRect(screen, sidebarX, 0, sidebarWidth, screenHeight, colorSidebar, false)
+	x := int(sidebarX) + 10
+	g.drawTextScaled(screen, tr("Leaderboard"), x, 30, spectatorTextScale)
+	for i, result := range s.leaderboard {
+		g.drawTextScaled(screen, strconv.Itoa(i+1)+". "+result.Name+"  "+formatNumber(result.Score), x, 60+i*18, 1)
+	}
+
+	qrSize := float64(s.qrCode.Bounds().Dx())
+	qrX := float64(sidebarX) + (sidebarWidth-qrSize)/2
+	qrY := screenHeight - qrSize - 30
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(qrX, qrY)
+	screen.DrawImage(s.qrCode, op)
+	g.drawTextCenteredOn(screen, tr("Scan to play!"), int(float64(sidebarX)+sidebarWidth/2), screenHeight-15)
+}
+
+func (g *Game) drawTextScaled(screen *ebiten.Image, content string, x, y int, scale float64) {
+	g.font.Scaled(scale).Draw(screen, content, float64(x), float64(y), text.AlignStart, 1)
+}
+
+func newQRCodeImage(content string) (*ebiten.Image, error) {
+	code, err := qr.Encode(content, qr.M)
+	if err != nil {
+		return nil, err
+	}
+	size := (code.Size + 2*qrQuietZone) * qrModuleSize
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	for y := range size {
+		for x := range size {
+			moduleX := x/qrModuleSize - qrQuietZone
+			moduleY := y/qrModuleSize - qrQuietZone
+			if code.Black(moduleX, moduleY) {
+				img.Set(x, y, color.Black)
+			} else {
+				img.Set(x, y, color.White)
+			}
+		}
+	}
+	return ebiten.NewImageFromImage(img), nil
+}
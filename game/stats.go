@@ -4,6 +4,7 @@ type Stats struct {
 	jumpForce          float64
 	playerAcceleration float64
 	maxPlayerSpeed     float64
+	traction           float64 // how much of the player's speed is lost every frame they aren't running
 }
 
 func (p *Stats) SetJumpForce(jumpForce float64) {
@@ -18,8 +19,14 @@ func (p *Stats) SetMaxPlayerSpeed(maxPlayerSpeed float64) {
 	p.maxPlayerSpeed = maxPlayerSpeed
 }
 
+func (p *Stats) SetTraction(traction float64) {
+	p.traction = traction
+}
+
 func (p *Stats) GetJumpForce() float64 { return p.jumpForce }
 
 func (p *Stats) GetPlayerAcceleration() float64 { return p.playerAcceleration }
 
 func (p *Stats) GetMaxPlayerSpeed() float64 { return p.maxPlayerSpeed }
+
+func (p *Stats) GetTraction() float64 { return p.traction }
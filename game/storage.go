@@ -0,0 +1,43 @@
+//go:build js && wasm
+// +build js,wasm
+
+package game
+
+import (
+	"log"
+	"syscall/js"
+)
+
+const storagePrefix = "geocode-jumper:"
+
+// loadValue reads from the browser's localStorage, which can be missing or blocked in private windows
+func loadValue(key string) (value string, ok bool) {
+	withStorage("Not loading "+key, func(storage js.Value) {
+		item := storage.Call("getItem", storagePrefix+key)
+		if !item.IsNull() {
+			value, ok = item.String(), true
+		}
+	})
+	return value, ok
+}
+
+func saveValue(key, value string) {
+	withStorage("Not saving "+key, func(storage js.Value) {
+		storage.Call("setItem", storagePrefix+key, value)
+	})
+}
+
+// withStorage calls use with localStorage if there is one. Just touching it throws a SecurityError in sandboxed
+// iframes and when the player blocks storage, and setItem throws when it's full, syscall/js turns those into panics
+func withStorage(failure string, use func(storage js.Value)) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println(failure+":", r)
+		}
+	}()
+	storage := js.Global().Get("localStorage")
+	if !storage.Truthy() {
+		return
+	}
+	use(storage)
+}
@@ -0,0 +1,62 @@
+//go:build !js || !wasm
+// +build !js !wasm
+
+package game
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+const storageFile = "geocode-jumper/storage.json"
+
+// loadValue reads from a JSON file in the user's config directory
+func loadValue(key string) (string, bool) {
+	values := loadStorage()
+	value, ok := values[key]
+	return value, ok
+}
+
+func saveValue(key, value string) {
+	path, err := storagePath()
+	if err != nil {
+		log.Println("Not saving", key+":", err)
+		return
+	}
+	values := loadStorage()
+	values[key] = value
+	data, err := json.Marshal(values)
+	if err == nil {
+		err = os.MkdirAll(filepath.Dir(path), 0o755)
+	}
+	if err == nil {
+		err = os.WriteFile(path, data, 0o644)
+	}
+	if err != nil {
+		log.Println("Not saving", key+":", err)
+	}
+}
+
+func loadStorage() map[string]string {
+	values := map[string]string{}
+	path, err := storagePath()
+	if err != nil {
+		return values
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return values
+	}
+	_ = json.Unmarshal(data, &values)
+	return values
+}
+
+func storagePath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, storageFile), nil
+}
@@ -0,0 +1,121 @@
+package game
+
+import (
+	"bytes"
+	"image/color"
+	"log"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+const (
+	bodyFontSize    = 16
+	hudFontSize     = 20
+	buttonFontSize  = 16
+	geocodeFontSize = 14
+	toggleFontSize  = 14
+	lineSpacing     = 1.2 // multiplied by the font size
+)
+
+type TextStyle int
+
+const (
+	TextPlain   TextStyle = iota
+	TextShadow            // a drop shadow down and to the right
+	TextOutline           // a border all the way around, readable over both the sky and the buildings
+)
+
+var (
+	colorTextOutline = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	colorTextShadow  = color.RGBA{A: 140}
+
+	regularFontSource = loadFontSource(goregular.TTF)
+	boldFontSource    = loadFontSource(gobold.TTF)
+
+	outlineOffsets = [][2]float64{{-1, -1}, {0, -1}, {1, -1}, {-1, 0}, {1, 0}, {-1, 1}, {0, 1}, {1, 1}}
+)
+
+// Font is a face with a color and style, the fonts are bundled so they look the same everywhere
+type Font struct {
+	face    *text.GoTextFace
+	style   TextStyle
+	color   color.Color
+	outline color.Color
+	weight  float64 // multiplies the thickness of the outline or shadow
+}
+
+func loadFontSource(ttf []byte) *text.GoTextFaceSource {
+	source, err := text.NewGoTextFaceSource(bytes.NewReader(ttf))
+	if err != nil {
+		log.Fatal(err)
+	}
+	return source
+}
+
+func NewFont(source *text.GoTextFaceSource, size float64, style TextStyle) *Font {
+	return &Font{
+		face:    &text.GoTextFace{Source: source, Size: size},
+		style:   style,
+		color:   colorText,
+		outline: colorTextOutline,
+		weight:  1,
+	}
+}
+
+// HighContrast returns a copy in white with a thick black outline, for players who have trouble reading the normal text
+func (f *Font) HighContrast() *Font {
+	contrast := *f
+	contrast.style = TextOutline
+	contrast.color = color.White
+	contrast.outline = color.Black
+	contrast.weight = highContrastOutlineBoost
+	return &contrast
+}
+
+// Scaled returns a copy of the font at a multiple of its size, glyphs are rendered at the new size instead of stretched
+func (f *Font) Scaled(scale float64) *Font {
+	scaled := *f
+	scaled.face = &text.GoTextFace{Source: f.face.Source, Size: f.face.Size * scale}
+	return &scaled
+}
+
+func (f *Font) WithStyle(style TextStyle) *Font {
+	styled := *f
+	styled.style = style
+	return &styled
+}
+
+func (f *Font) Measure(content string) (width, height float64) {
+	return text.Measure(content, f.face, f.face.Size*lineSpacing)
+}
+
+// Draw aligns the text horizontally on x and centers it vertically on y
+func (f *Font) Draw(screen *ebiten.Image, content string, x, y float64, align text.Align, alpha float32) {
+	op := &text.DrawOptions{}
+	op.LayoutOptions.PrimaryAlign = align
+	op.LayoutOptions.SecondaryAlign = text.AlignCenter
+	op.LayoutOptions.LineSpacing = f.face.Size * lineSpacing
+
+	thickness := max(1, f.face.Size/16) * f.weight
+	switch f.style {
+	case TextShadow:
+		f.drawAt(screen, content, x+thickness, y+thickness, colorTextShadow, alpha, op)
+	case TextOutline:
+		for _, offset := range outlineOffsets {
+			f.drawAt(screen, content, x+offset[0]*thickness, y+offset[1]*thickness, f.outline, alpha, op)
+		}
+	}
+	f.drawAt(screen, content, x, y, f.color, alpha, op)
+}
+
+func (f *Font) drawAt(screen *ebiten.Image, content string, x, y float64, clr color.Color, alpha float32, op *text.DrawOptions) {
+	op.GeoM.Reset()
+	op.GeoM.Translate(x, y)
+	op.ColorScale.Reset()
+	op.ColorScale.ScaleWithColor(clr)
+	op.ColorScale.ScaleAlpha(alpha)
+	text.Draw(screen, content, f.face, op)
+}
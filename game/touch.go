@@ -0,0 +1,186 @@
+package game
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// TouchScheme is how a phone player runs and jumps
+type TouchScheme int
+
+const (
+	TouchOneTap  TouchScheme = iota // runs right on its own, tap anywhere to jump
+	TouchButtons                    // on-screen buttons for left, right and jump
+	TouchSwipe                      // drag sideways to run, swipe up to jump
+	numTouchSchemes
+)
+
+var touchSchemeNames = [numTouchSchemes]string{
+	TouchOneTap:  "One tap",
+	TouchButtons: "Buttons",
+	TouchSwipe:   "Swipe",
+}
+
+const (
+	touchButtonSize    = 64
+	touchButtonSpacing = 16
+	touchButtonMargin  = 24
+	swipeRunDistance   = 20 // how far a finger has to be dragged sideways before the player runs
+	swipeJumpDistance  = 30
+)
+
+var colorTouchButton = color.NRGBA{R: 255, G: 255, B: 255, A: 90}
+
+type swipe struct {
+	startX, startY int
+	jumped         bool // one swipe is one jump, however far it goes
+}
+
+// TouchControls turns the fingers on the screen into actions, so the game reads them the same way it reads keys
+type TouchControls struct {
+	left, right, jump *Button
+	held              [numActions]bool
+	justPressed       [numActions]bool
+	ids               []ebiten.TouchID // reused every frame
+	swipes            map[ebiten.TouchID]*swipe
+	shown             bool // the buttons are on screen, so other things in the bottom corners make way
+}
+
+var touches = TouchControls{swipes: map[ebiten.TouchID]*swipe{}}
+
+func (tc *TouchControls) init() {
+	tc.left = newTouchButton("◄")
+	tc.right = newTouchButton("►")
+	tc.jump = newTouchButton("▲")
+	tc.layout()
+}
+
+func newTouchButton(label string) *Button {
+	b := NewButton(0, 0, touchButtonSize, touchButtonSize, touchButtonSpacing/2, func() {})
+	b.drawMoreStrategy = TextStrategy{
+		bg:       TouchButtonStrategy{},
+		font:     NewFont(boldFontSource, hudFontSize, TextOutline),
+		textFunc: func() string { return label },
+	}
+	return b
+}
+
+// layout puts left and right under the left thumb and jump under the right thumb
+func (tc *TouchControls) layout() {
+	y := float64(screenHeight - touchButtonMargin - touchButtonSize/2)
+	tc.left.SetCenter(touchButtonMargin+touchButtonSize/2, y)
+	tc.right.SetCenter(touchButtonMargin+touchButtonSize*1.5+touchButtonSpacing, y)
+	tc.jump.SetCenter(screenWidth-touchButtonMargin-touchButtonSize/2, y)
+}
+
+// Update works out which actions the fingers on the screen are holding down this frame
+func (tc *TouchControls) Update(g *Game) {
+	wasHeld := tc.held
+	tc.held = [numActions]bool{}
+	tc.justPressed = [numActions]bool{}
+	tc.shown = g.isMobile && settings.TouchScheme == TouchButtons && g.gameStarted && !g.gameOver && !g.paused
+	if !g.isMobile || settings.TouchScheme == TouchOneTap {
+		return // one tap goes through the click handler, like it always has
+	}
+
+	tc.ids = ebiten.AppendTouchIDs(tc.ids[:0])
+	switch settings.TouchScheme {
+	case TouchButtons:
+		tc.updateButtons()
+	case TouchSwipe:
+		tc.updateSwipes()
+	}
+	for action := range numActions {
+		if tc.held[action] && !wasHeld[action] {
+			tc.justPressed[action] = true
+		}
+	}
+}
+
+func (tc *TouchControls) updateButtons() {
+	for _, id := range tc.ids {
+		x, y := ebiten.TouchPosition(id)
+		switch {
+		case tc.left.Overlaps(x, y):
+			tc.held[MoveLeft] = true
+		case tc.right.Overlaps(x, y):
+			tc.held[MoveRight] = true
+		case tc.jump.Overlaps(x, y):
+			tc.held[Jump] = true
+		}
+	}
+	tc.left.isPressed = tc.held[MoveLeft]
+	tc.right.isPressed = tc.held[MoveRight]
+	tc.jump.isPressed = tc.held[Jump]
+}
+
+// updateSwipes keeps running while a finger stays dragged to one side, and keeps the jump held while a finger that
+// swiped up stays down so it jumps higher
+func (tc *TouchControls) updateSwipes() {
+	for id := range tc.swipes {
+		if !containsTouch(tc.ids, id) {
+			delete(tc.swipes, id)
+		}
+	}
+	for _, id := range tc.ids {
+		x, y := ebiten.TouchPosition(id)
+		s, ok := tc.swipes[id]
+		if !ok {
+			tc.swipes[id] = &swipe{startX: x, startY: y}
+			continue
+		}
+		dx, dy := x-s.startX, y-s.startY
+		if dx < -swipeRunDistance {
+			tc.held[MoveLeft] = true
+		} else if dx > swipeRunDistance {
+			tc.held[MoveRight] = true
+		}
+		if dy < -swipeJumpDistance {
+			if !s.jumped {
+				s.jumped = true
+				tc.justPressed[Jump] = true
+			}
+			tc.held[Jump] = true
+		}
+	}
+}
+
+func containsTouch(ids []ebiten.TouchID, id ebiten.TouchID) bool {
+	for _, other := range ids {
+		if other == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (tc *TouchControls) Draw(screen *ebiten.Image) {
+	if !tc.shown {
+		return
+	}
+	tc.left.Draw(screen)
+	tc.right.Draw(screen)
+	tc.jump.Draw(screen)
+}
+
+// TouchButtonStrategy is see-through so the buttons don't hide the rooftops under them, and lights up while held
+type TouchButtonStrategy struct {
+}
+
+func (ts TouchButtonStrategy) DrawButton(screen *ebiten.Image, b *Button) {
+	clr := colorTouchButton
+	if b.isPressed {
+		clr.A *= 2
+	}
+	cx, cy := float32(b.x+b.width/2), float32(b.y+b.height/2)
+	vector.DrawFilledCircle(screen, cx, cy, float32(b.width/2), clr, true)
+	vector.StrokeCircle(screen, cx, cy, float32(b.width/2), 2, color.White, true)
+}
+
+// cycleTouchScheme switches to the next way of playing on a phone
+func cycleTouchScheme() {
+	settings.TouchScheme = (settings.TouchScheme + 1) % numTouchSchemes
+	saveSettings()
+}
@@ -0,0 +1,37 @@
+package game
+
+// usOutline is a rough outline of the continental US as longitude, latitude pairs, going clockwise from the
+// northwest corner. It's only meant to be recognizable on a small map, so the coasts and borders are simplified a lot.
+var usOutline = [][2]float64{
+	// west coast
+	{-124.7, 48.4}, {-124.1, 46.9}, {-124.0, 46.2}, {-124.1, 43.7}, {-124.4, 42.0}, {-124.2, 40.4},
+	{-123.8, 39.5}, {-122.5, 37.8}, {-121.9, 36.6}, {-120.6, 34.6}, {-118.5, 34.0}, {-117.1, 32.5},
+	// mexican border
+	{-114.7, 32.7}, {-111.1, 31.3}, {-108.2, 31.3}, {-106.5, 31.8}, {-104.5, 29.6}, {-103.1, 29.0},
+	{-101.4, 29.8}, {-99.5, 27.5}, {-97.4, 25.9},
+	// gulf coast and florida
+	{-97.4, 27.8}, {-96.0, 28.6}, {-94.0, 29.7}, {-92.0, 29.6}, {-90.0, 29.1}, {-89.4, 30.2},
+	{-88.0, 30.7}, {-86.5, 30.4}, {-85.0, 29.7}, {-84.0, 30.0}, {-82.8, 29.0}, {-82.6, 27.5},
+	{-81.8, 26.1}, {-80.9, 25.1}, {-80.1, 25.8}, {-80.0, 26.9}, {-80.6, 28.6}, {-81.3, 30.5},
+	// east coast
+	{-81.1, 31.9}, {-79.2, 33.2}, {-77.9, 34.0}, {-75.5, 35.2}, {-76.0, 36.9}, {-75.0, 38.5},
+	{-74.0, 39.7}, {-74.0, 40.5}, {-71.9, 41.3}, {-70.0, 41.7}, {-70.6, 42.6}, {-70.2, 43.7},
+	{-68.0, 44.4}, {-67.0, 44.8},
+	// canadian border and the great lakes
+	{-67.8, 47.1}, {-69.2, 47.4}, {-71.5, 45.0}, {-74.7, 45.0}, {-76.3, 44.2}, {-79.0, 43.3},
+	{-79.8, 42.2}, {-82.5, 41.7}, {-83.5, 41.9}, {-82.4, 43.0}, {-82.5, 45.3}, {-84.5, 46.5},
+	{-88.0, 47.4}, {-89.6, 48.0}, {-95.2, 49.4}, {-95.2, 49.0}, {-123.3, 49.0}, {-122.8, 48.1},
+}
+
+// insideUS is a ray casting point in polygon test against the outline
+func insideUS(lat, lon float64) bool {
+	inside := false
+	for i, j := 0, len(usOutline)-1; i < len(usOutline); j, i = i, i+1 {
+		lonI, latI := usOutline[i][0], usOutline[i][1]
+		lonJ, latJ := usOutline[j][0], usOutline[j][1]
+		if (latI > lat) != (latJ > lat) && lon < (lonJ-lonI)*(lat-latI)/(latJ-latI)+lonI {
+			inside = !inside
+		}
+	}
+	return inside
+}
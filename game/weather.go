@@ -0,0 +1,172 @@
+package game
+
+import (
+	"image/color"
+	"math"
+	"math/rand"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	weatherMilestone    = 15 // the weather can change every time the score passes a multiple of this
+	maxWeatherParticles = 200
+	weatherFadeRate     = 0.01
+	windSpeed           = 0.4  // how much faster the player can run with the wind, and slower against it
+	windPush            = 0.1  // how much the strongest wind changes the player's speed every frame
+	wetTraction         = 0.05 // wet roofs barely slow the player down
+	cloudWindDrift      = 2.0
+)
+
+type WeatherKind int
+
+const (
+	Clear WeatherKind = iota
+	Rain
+	Snow
+	Wind
+)
+
+var (
+	colorRain = color.NRGBA{R: 160, G: 180, B: 220, A: 180}
+	colorSnow = color.NRGBA{R: 255, G: 255, B: 255, A: 230}
+	colorGust = color.NRGBA{R: 255, G: 255, B: 255, A: 90}
+)
+
+type Weather struct {
+	kind       WeatherKind
+	milestone  int
+	wind       float64 // pushes the player and the clouds, negative is a headwind
+	traction   float64
+	cloudShade float32
+	intensity  float32 // ramps up so the weather doesn't pop in
+	particles  [maxWeatherParticles]weatherParticle
+}
+
+type weatherParticle struct {
+	Pos
+	speedX, speedY float64
+	phase          float64
+}
+
+func NewWeather(kind WeatherKind, milestone int) *Weather {
+	w := &Weather{kind: kind, milestone: milestone, traction: startingTraction, cloudShade: 1}
+	switch kind {
+	case Rain:
+		w.traction = wetTraction
+		w.cloudShade = .6
+		w.wind = windSpeed / 4
+	case Snow:
+		w.cloudShade = .85
+	case Wind:
+		w.wind = windSpeed
+		if milestone%2 == 0 {
+			w.wind = -windSpeed
+		}
+	}
+	for i := range w.particles {
+		w.resetParticle(&w.particles[i], rand.Float64()*screenHeight)
+	}
+	return w
+}
+
+// pickWeather uses the seed so everyone racing the same city gets the same weather
+func pickWeather(seed int64, milestone int) WeatherKind {
+	if milestone == 0 {
+		return Clear
+	}
+	rng := rand.New(rand.NewSource(seed + int64(milestone)))
+	kinds := []WeatherKind{Clear, Rain, Snow, Wind}
+	return kinds[rng.Intn(len(kinds))]
+}
+
+func (w *Weather) resetParticle(p *weatherParticle, y float64) {
+	p.x = rand.Float64() * maxScreenWidth
+	p.y = y
+	p.phase = rand.Float64() * math.Pi * 2
+	switch w.kind {
+	case Rain:
+		p.speedX = w.wind * 4
+		p.speedY = 9 + rand.Float64()*3
+	case Snow:
+		p.speedX = w.wind
+		p.speedY = 0.8 + rand.Float64()*0.8
+	case Wind:
+		p.speedX = w.wind * (20 + rand.Float64()*10)
+		p.speedY = 0.2
+	}
+}
+
+func (w *Weather) Update() {
+	if w.kind == Clear {
+		return
+	}
+	w.intensity = min(w.intensity+weatherFadeRate, 1)
+	for i := range w.particles {
+		p := &w.particles[i]
+		p.x += p.speedX
+		p.y += p.speedY
+		if w.kind == Snow {
+			p.x += math.Sin(p.y/30+p.phase) * 0.5
+		}
+		if p.y > screenHeight || p.x < -20 || p.x > maxScreenWidth+20 {
+			w.resetParticle(p, -10)
+		}
+	}
+}
+
+// Draw puts the weather over the whole scene, in screen coordinates
+func (w *Weather) Draw(screen *ebiten.Image) {
+	if w.kind == Clear {
+		return
+	}
+	visible := int(float32(len(w.particles)) * w.intensity)
+	for _, p := range w.particles[:visible] {
+		x, y := float32(p.x), float32(p.y)
+		switch w.kind {
+		case Rain:
+			vector.StrokeLine(screen, x, y, x+float32(p.speedX), y+float32(p.speedY), 1, colorRain, false)
+		case Snow:
+			vector.DrawFilledCircle(screen, x, y, 1.5, colorSnow, false)
+		case Wind:
+			vector.StrokeLine(screen, x, y, x-float32(p.speedX), y, 1, colorGust, false)
+		}
+	}
+}
+
+func (g *Game) handleWeather() {
+	milestone := g.score / weatherMilestone
+	if milestone != g.weather.milestone {
+		g.weather = NewWeather(pickWeather(g.seed, milestone), milestone)
+	}
+	g.weather.Update()
+	for _, cloud := range g.clouds {
+		cloud.x += g.weather.wind * cloudWindDrift
+	}
+	if settings.WeatherPhysics {
+		g.player.SetTraction(g.weather.traction)
+	} else {
+		g.player.SetTraction(startingTraction)
+	}
+}
+
+// applyWind nudges the player's speed along with the wind, it's what makes windy rooftops tricky. The push goes
+// through velocityX, so traction still holds a player standing on a dry roof, and the wind can only shift their top
+// speed by windSpeed
+func (g *Game) applyWind() {
+	wind := g.windSpeed()
+	if wind == 0 {
+		return
+	}
+	topSpeed := g.player.GetMaxPlayerSpeed() + g.player.GetPlayerAcceleration() // running tops out a step over the max
+	velocity := g.player.velocityX + wind*windPush/windSpeed
+	g.player.velocityX = max(-topSpeed+wind, min(velocity, topSpeed+wind))
+}
+
+func (g *Game) windSpeed() float64 {
+	if !settings.WeatherPhysics {
+		return 0
+	}
+	return g.weather.wind
+}
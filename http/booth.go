@@ -0,0 +1,117 @@
+package main
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/smarty-archives/rooftop-geocoding-game/race"
+	"golang.org/x/net/websocket"
+)
+
+// booth relays the runs played on the booth machines to the spectators watching the big screen
+type booth struct {
+	name        string
+	spectators  map[int]*racer
+	live        *racer // the runner whose run the spectators are following
+	leaderboard []race.Result
+}
+
+func (s *raceServer) getBooth(name string) *booth {
+	b, ok := s.booths[name]
+	if !ok {
+		b = &booth{name: name, spectators: map[int]*racer{}}
+		s.booths[name] = b
+	}
+	return b
+}
+
+func (s *raceServer) serveBoothRunner(conn *websocket.Conn, first race.Message) {
+	s.mu.Lock()
+	b := s.getBooth(first.Room)
+	me := s.newRacer(first.Name, conn)
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		if b.live == me {
+			b.live = nil
+		}
+		s.mu.Unlock()
+		me.stop()
+	}()
+
+	msg := first
+	for {
+		s.handleBoothRun(b, me, msg)
+		msg = race.Message{}
+		if err := websocket.JSON.Receive(conn, &msg); err != nil {
+			return
+		}
+	}
+}
+
+func (s *raceServer) handleBoothRun(b *booth, me *racer, msg race.Message) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch msg.Type {
+	case race.TypeRun: // the newest run is the one worth watching
+		if msg.Name != "" {
+			me.name = msg.Name
+		}
+		me.fell = false
+		b.live = me
+		b.broadcast(race.Message{Type: race.TypeRun, ID: me.id, Name: me.name, Seed: msg.Seed})
+	case race.TypeState:
+		if b.live == me && msg.State != nil {
+			for _, spectator := range b.spectators {
+				spectator.sendFrame(race.Message{Type: race.TypeState, ID: me.id, State: msg.State})
+			}
+		}
+	case race.TypeFell:
+		if me.fell {
+			return
+		}
+		me.fell = true
+		b.record(race.Result{ID: me.id, Name: me.name, Score: msg.Score})
+		if b.live == me {
+			b.broadcast(race.Message{Type: race.TypeFell, ID: me.id, Score: msg.Score})
+		}
+		b.broadcast(race.Message{Type: race.TypeLeaderboard, Results: slices.Clone(b.leaderboard)})
+	}
+}
+
+func (s *raceServer) serveSpectator(conn *websocket.Conn, first race.Message) {
+	s.mu.Lock()
+	b := s.getBooth(first.Room)
+	me := s.newRacer(first.Name, conn)
+	b.spectators[me.id] = me
+	me.send(race.Message{Type: race.TypeLeaderboard, Results: slices.Clone(b.leaderboard)})
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(b.spectators, me.id)
+		s.mu.Unlock()
+		me.stop()
+	}()
+
+	// spectators only listen, so this just waits for the connection to close
+	for {
+		var msg race.Message
+		if err := websocket.JSON.Receive(conn, &msg); err != nil {
+			return
+		}
+	}
+}
+
+func (b *booth) record(result race.Result) {
+	b.leaderboard = append(b.leaderboard, result)
+	sort.SliceStable(b.leaderboard, func(i, j int) bool { return b.leaderboard[i].Score > b.leaderboard[j].Score })
+	if len(b.leaderboard) > race.LeaderboardSize {
+		b.leaderboard = b.leaderboard[:race.LeaderboardSize]
+	}
+}
+
+func (b *booth) broadcast(msg race.Message) {
+	for _, spectator := range b.spectators {
+		spectator.send(msg)
+	}
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"log"
+	"maps"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	filesDir          = "./files"
+	devReloadPath     = "_dev/reload"
+	devPollInterval   = 500 * time.Millisecond
+	devReloadEventMsg = "data: reload\n\n"
+)
+
+// devServer rebuilds main.wasm whenever the Go sources or files/ change and tells open tabs to reload
+type devServer struct {
+	mu      sync.Mutex
+	clients map[chan struct{}]struct{}
+}
+
+func newDevServer() *devServer {
+	return &devServer{clients: map[chan struct{}]struct{}{}}
+}
+
+func (d *devServer) watch() {
+	last := snapshotSources()
+	for range time.Tick(devPollInterval) {
+		current := snapshotSources()
+		if maps.Equal(last, current) {
+			continue
+		}
+		last = current
+		log.Println("Change detected, rebuilding...")
+		if err := rebuild(); err != nil {
+			log.Println("Rebuild failed:", err)
+			continue
+		}
+		log.Println("Rebuilt, reloading browsers")
+		d.notify()
+	}
+}
+
+func (d *devServer) notify() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for client := range d.clients {
+		select {
+		case client <- struct{}{}:
+		default: // a reload is already pending for this client
+		}
+	}
+}
+
+// ServeHTTP streams a server-sent event to the browser every time the game is rebuilt
+func (d *devServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	flusher.Flush()
+
+	client := make(chan struct{}, 1)
+	d.mu.Lock()
+	d.clients[client] = struct{}{}
+	d.mu.Unlock()
+	defer func() {
+		d.mu.Lock()
+		delete(d.clients, client)
+		d.mu.Unlock()
+	}()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-client:
+			fmt.Fprint(w, devReloadEventMsg)
+			flusher.Flush()
+		}
+	}
+}
+
+// snapshotSources records the modification time of every file that should trigger a rebuild
+func snapshotSources() map[string]time.Time {
+	snapshot := map[string]time.Time{}
+	_ = filepath.WalkDir(".", func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if entry.IsDir() {
+			if path != "." && (strings.HasPrefix(entry.Name(), ".") || path == "static" || path == "assets") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") && !strings.HasPrefix(path, "files"+string(filepath.Separator)) {
+			return nil
+		}
+		if info, err := entry.Info(); err == nil {
+			snapshot[path] = info.ModTime()
+		}
+		return nil
+	})
+	return snapshot
+}
+
+// rebuild does the same work as `make compile` without fetching the assets again
+func rebuild() error {
+	entries, err := os.ReadDir(filesDir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(filesDir, entry.Name()))
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(staticDir, entry.Name()), data, 0o644); err != nil {
+			return err
+		}
+	}
+
+	cmd := exec.Command("go", "build", "-o", filepath.Join(staticDir, "main.wasm"), ".")
+	cmd.Env = append(os.Environ(), "GOOS=js", "GOARCH=wasm")
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
@@ -1,15 +1,84 @@
 package main
 
 import (
+	"flag"
+	"html/template"
 	"log"
 	"net/http"
+	"path/filepath"
+	"strings"
 )
 
+const staticDir = "./static" // Serve files from the "static" folder
+
+var (
+	port     = flag.String("port", "8080", "port to serve on")
+	basePath = flag.String("base", "/", "path the game is hosted under, e.g. /geocode-game/")
+	gameLink = flag.String("link", "https://www.smarty.com/geocode-jumper", "link included in the share text")
+	devMode  = flag.Bool("dev", false, "rebuild main.wasm when the sources change and reload open tabs")
+)
+
+type page struct {
+	BasePath string
+	GameLink string
+	Dev      bool
+}
+
 func main() {
-	fs := http.FileServer(http.Dir("./static")) // Serve files from the "static" folder
-	http.Handle("/", fs)
+	flag.Parse()
+	base := normalizeBasePath(*basePath)
+
+	index, err := parseIndex()
+	if err != nil {
+		log.Fatal(err)
+	}
+	data := page{BasePath: base, GameLink: *gameLink, Dev: *devMode}
+
+	if *devMode {
+		dev := newDevServer()
+		go dev.watch()
+		http.Handle(base+devReloadPath, dev)
+	}
+
+	http.Handle(base+racePath, newRaceServer().Handler())
+
+	fs := http.StripPrefix(base, http.FileServer(http.Dir(staticDir)))
+	http.HandleFunc(base, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == base || r.URL.Path == base+"index.html" {
+			tmpl := index
+			if *devMode { // index.html may have changed since the last rebuild
+				reparsed, err := parseIndex()
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusInternalServerError)
+					return
+				}
+				tmpl = reparsed
+			}
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			if err := tmpl.Execute(w, data); err != nil {
+				log.Println(err)
+			}
+			return
+		}
+		fs.ServeHTTP(w, r)
+	})
+	if base != "/" {
+		http.Handle(strings.TrimSuffix(base, "/"), http.RedirectHandler(base, http.StatusMovedPermanently))
+	}
+
+	log.Println("Serving on http://localhost:" + *port + base)
+	log.Fatal(http.ListenAndServe(":"+*port, nil))
+}
+
+func parseIndex() (*template.Template, error) {
+	return template.ParseFiles(filepath.Join(staticDir, "index.html"))
+}
 
-	port := "8080"
-	log.Println("Serving on http://localhost:" + port)
-	log.Fatal(http.ListenAndServe(":"+port, nil))
+// normalizeBasePath makes sure the path starts and ends with a slash so it can be used as a prefix for the game's files
+func normalizeBasePath(path string) string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return "/"
+	}
+	return "/" + path + "/"
 }
@@ -0,0 +1,280 @@
+package main
+
+import (
+	"log"
+	"math/rand"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/smarty-archives/rooftop-geocoding-game/race"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	racePath     = "race"
+	outboxSize   = 64 // state frames are skipped when it fills up, so this only has to hold the other messages
+	writeTimeout = 5 * time.Second
+)
+
+// raceServer puts players into rooms, hands out a shared seed and relays where everyone is
+type raceServer struct {
+	mu     sync.Mutex
+	rooms  map[string]*room
+	booths map[string]*booth
+	nextID int
+}
+
+type room struct {
+	name    string
+	racers  map[int]*racer
+	order   []int // join order, the first one is the host
+	started bool
+}
+
+// racer is anyone connected to the server. Messages to them are queued and written by their own goroutine, so a slow
+// connection never holds up the others while s.mu is held
+type racer struct {
+	id     int
+	name   string
+	conn   *websocket.Conn
+	outbox chan race.Message
+	done   chan struct{}
+	score  int
+	fell   bool
+}
+
+func newRaceServer() *raceServer {
+	return &raceServer{rooms: map[string]*room{}, booths: map[string]*booth{}}
+}
+
+func (s *raceServer) Handler() websocket.Handler {
+	return s.serve
+}
+
+// serve works out from the first message whether the connection is a racer, a booth runner or a spectator
+func (s *raceServer) serve(conn *websocket.Conn) {
+	defer conn.Close()
+
+	var first race.Message
+	if err := websocket.JSON.Receive(conn, &first); err != nil || first.Room == "" {
+		_ = websocket.JSON.Send(conn, race.Message{Type: race.TypeError, Error: "join a room first"})
+		return
+	}
+	switch first.Type {
+	case race.TypeJoin:
+		s.serveRacer(conn, first)
+	case race.TypeRun:
+		s.serveBoothRunner(conn, first)
+	case race.TypeWatch:
+		s.serveSpectator(conn, first)
+	default:
+		_ = websocket.JSON.Send(conn, race.Message{Type: race.TypeError, Error: "join a room first"})
+	}
+}
+
+func (s *raceServer) serveRacer(conn *websocket.Conn, join race.Message) {
+	r, me, errMsg := s.join(join.Room, join.Name, conn)
+	if errMsg != "" {
+		_ = websocket.JSON.Send(conn, race.Message{Type: race.TypeError, Error: errMsg})
+		return
+	}
+	defer me.stop()
+	defer s.leave(r, me)
+
+	me.send(race.Message{Type: race.TypeWelcome, ID: me.id, Room: r.name})
+	s.mu.Lock()
+	r.broadcastLobby()
+	s.mu.Unlock()
+
+	for {
+		var msg race.Message
+		if err := websocket.JSON.Receive(conn, &msg); err != nil {
+			return
+		}
+		s.handle(r, me, msg)
+	}
+}
+
+func (s *raceServer) join(roomName, name string, conn *websocket.Conn) (*room, *racer, string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.rooms[roomName]
+	if !ok {
+		r = &room{name: roomName, racers: map[int]*racer{}}
+		s.rooms[roomName] = r
+	}
+	if r.started {
+		return nil, nil, "this race already started"
+	}
+	if len(r.racers) >= race.MaxPlayers {
+		return nil, nil, "this room is full"
+	}
+	if name == "" {
+		name = "Runner " + strconv.Itoa(len(r.racers)+1)
+	}
+	me := s.newRacer(name, conn)
+	r.racers[me.id] = me
+	r.order = append(r.order, me.id)
+	return r, me, ""
+}
+
+// newRacer must be called while holding s.mu, and stop called once the connection is done
+func (s *raceServer) newRacer(name string, conn *websocket.Conn) *racer {
+	s.nextID++
+	rc := &racer{id: s.nextID, name: name, conn: conn, outbox: make(chan race.Message, outboxSize), done: make(chan struct{})}
+	go rc.write()
+	return rc
+}
+
+func (s *raceServer) leave(r *room, me *racer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(r.racers, me.id)
+	for i, id := range r.order {
+		if id == me.id {
+			r.order = append(r.order[:i], r.order[i+1:]...)
+			break
+		}
+	}
+	if len(r.racers) == 0 {
+		delete(s.rooms, r.name)
+		return
+	}
+	r.broadcast(race.Message{Type: race.TypeLeft, ID: me.id}, 0)
+	if r.started && r.everyoneFell() {
+		r.finish()
+	}
+	r.broadcastLobby()
+}
+
+func (s *raceServer) handle(r *room, me *racer, msg race.Message) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch msg.Type {
+	case race.TypeStart:
+		if r.started || r.hostID() != me.id {
+			return
+		}
+		if len(r.racers) < race.MinPlayers {
+			me.send(race.Message{Type: race.TypeError, Error: "waiting for more runners"})
+			return
+		}
+		r.started = true
+		for _, other := range r.racers {
+			other.fell = false
+			other.score = 0
+		}
+		r.broadcast(race.Message{Type: race.TypeStart, Seed: rand.Int63()}, 0)
+	case race.TypeState:
+		if !r.started || me.fell || msg.State == nil {
+			return
+		}
+		me.score = msg.State.Score
+		r.broadcastFrame(race.Message{Type: race.TypeState, ID: me.id, State: msg.State}, me.id)
+	case race.TypeFell:
+		if !r.started || me.fell {
+			return
+		}
+		me.fell = true
+		me.score = msg.Score
+		r.broadcast(race.Message{Type: race.TypeFell, ID: me.id, Score: msg.Score}, me.id)
+		if r.everyoneFell() {
+			r.finish()
+		}
+	}
+}
+
+func (r *room) hostID() int {
+	if len(r.order) == 0 {
+		return 0
+	}
+	return r.order[0]
+}
+
+func (r *room) everyoneFell() bool {
+	for _, other := range r.racers {
+		if !other.fell {
+			return false
+		}
+	}
+	return true
+}
+
+// finish announces the results and puts the room back in the lobby so the same runners can go again
+func (r *room) finish() {
+	var results []race.Result
+	for _, id := range r.order {
+		other := r.racers[id]
+		results = append(results, race.Result{ID: other.id, Name: other.name, Score: other.score})
+	}
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Score > results[j].Score })
+	r.started = false
+	r.broadcast(race.Message{Type: race.TypeResults, Results: results}, 0)
+}
+
+func (r *room) broadcastLobby() {
+	var players []race.PlayerInfo
+	for _, id := range r.order {
+		players = append(players, race.PlayerInfo{ID: id, Name: r.racers[id].name})
+	}
+	r.broadcast(race.Message{Type: race.TypeLobby, Room: r.name, HostID: r.hostID(), Players: players}, 0)
+}
+
+// broadcast sends the message to everyone in the room except the racer with the skip id
+func (r *room) broadcast(msg race.Message, skip int) {
+	for _, other := range r.racers {
+		if other.id != skip {
+			other.send(msg)
+		}
+	}
+}
+
+// broadcastFrame is broadcast for state frames, racers that are behind skip them
+func (r *room) broadcastFrame(msg race.Message, skip int) {
+	for _, other := range r.racers {
+		if other.id != skip {
+			other.sendFrame(msg)
+		}
+	}
+}
+
+// send queues the message without waiting, a connection too far behind to take it is closed
+func (rc *racer) send(msg race.Message) {
+	select {
+	case rc.outbox <- msg:
+	default:
+		log.Println("Disconnecting", rc.name+", too far behind")
+		_ = rc.conn.Close()
+	}
+}
+
+// sendFrame is for state frames, which come every frame and are out of date by the next one, so a connection that's
+// behind skips them instead of being closed
+func (rc *racer) sendFrame(msg race.Message) {
+	select {
+	case rc.outbox <- msg:
+	default:
+	}
+}
+
+func (rc *racer) write() {
+	for {
+		select {
+		case msg := <-rc.outbox:
+			_ = rc.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+			if err := websocket.JSON.Send(rc.conn, msg); err != nil {
+				log.Println("Could not send to", rc.name+":", err)
+				_ = rc.conn.Close()
+				return
+			}
+		case <-rc.done:
+			return
+		}
+	}
+}
+
+func (rc *racer) stop() {
+	close(rc.done)
+}
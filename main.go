@@ -5,6 +5,7 @@ package main
 
 import (
 	_ "embed"
+	"log"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/smarty-archives/rooftop-geocoding-game/game"
@@ -19,6 +20,22 @@ func main() {
 
 	// Initialize the game
 	g := game.NewGame()
+	if url, room, name, ok := game.RaceConfigFromPage(); ok {
+		if err := g.JoinRace(url, room, name); err != nil {
+			log.Println(err)
+		}
+	}
+	if url, booth, name, ok := game.BoothConfigFromPage(); ok {
+		if err := g.PublishRuns(url, booth, name); err != nil {
+			log.Println(err)
+		}
+	}
+	if url, booth, joinURL, ok := game.SpectatorConfigFromPage(); ok {
+		if err := g.Spectate(url, booth, joinURL); err != nil {
+			log.Println(err)
+		}
+	}
+	defer g.Close()
 	if err := ebiten.RunGame(g); err != nil {
 		panic(err)
 	}
@@ -4,12 +4,41 @@
 package main
 
 import (
+	"flag"
+	"log"
+
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/smarty-archives/rooftop-geocoding-game/game"
 )
 
+var (
+	raceServer = flag.String("race-server", "ws://localhost:8080/race", "websocket URL of the race server")
+	raceRoom   = flag.String("room", "", "join this race room instead of playing alone")
+	raceName   = flag.String("name", "", "name shown to the other runners and spectators")
+	booth      = flag.String("booth", "", "publish every run to the spectators of this booth")
+	spectate   = flag.String("spectate", "", "follow the runs of this booth on a big screen")
+	joinURL    = flag.String("join", "https://www.smarty.com/geocode-jumper", "link the spectator QR code points to")
+)
+
 func main() {
+	flag.Parse()
+	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
 	g := game.NewGame()
+	if *raceRoom != "" {
+		if err := g.JoinRace(*raceServer, *raceRoom, *raceName); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if *booth != "" {
+		if err := g.PublishRuns(*raceServer, *booth, *raceName); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if *spectate != "" {
+		if err := g.Spectate(*raceServer, *spectate, *joinURL); err != nil {
+			log.Fatal(err)
+		}
+	}
 	if err := ebiten.RunGame(g); err != nil {
 		panic(err)
 	}
@@ -0,0 +1,107 @@
+package media
+
+import (
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"io"
+	"log"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+const (
+	assetsFilePath         = "assets/"
+	backgroundsConfigFile  = "backgrounds.json"
+	TilingFitHeight        = "fit-height" // scaled to the screen height and repeated horizontally
+	TilingRepeatX          = "repeat-x"   // drawn at its own size and repeated horizontally
+	TilingStretch          = "stretch"    // stretched over the whole screen, it doesn't scroll
+	defaultBackgroundImage = "layer0.png"
+)
+
+// BackgroundLayer describes one layer of the parallax background. assets/backgrounds.json lists them back to front:
+//
+//	{"layers": [
+//		{"image": "sky.png", "tiling": "stretch"},
+//		{"image": "far-skyline.png", "speed": 0.1, "offsetY": 120, "tiling": "repeat-x", "tint": "#c8d0ff"},
+//		{"image": "layer0.png", "speed": 0.2},
+//		{"image": "haze.png", "speed": 1.2, "foreground": true, "tint": "#ffffff66"}
+//	]}
+type BackgroundLayer struct {
+	Image      string      `json:"image"`
+	Speed      float64     `json:"speed"`      // how fast the layer scrolls compared to the camera
+	OffsetY    float64     `json:"offsetY"`    // moves the layer down from the top of the screen
+	Tint       string      `json:"tint"`       // #rrggbb or #rrggbbaa, multiplied with the image
+	Tiling     string      `json:"tiling"`     // one of the Tiling modes, defaults to fit-height
+	Foreground bool        `json:"foreground"` // drawn in front of the buildings and the player
+	TintColor  color.NRGBA `json:"-"`
+}
+
+type backgroundsConfig struct {
+	Layers []BackgroundLayer `json:"layers"`
+}
+
+var defaultBackgroundLayers = []BackgroundLayer{
+	{Image: defaultBackgroundImage, Speed: 0.2, Tiling: TilingFitHeight},
+}
+
+func (m *Manager) GetBackgroundLayers() []BackgroundLayer {
+	return m.backgroundLayers
+}
+
+func (m *Manager) initializeBackgroundLayers() {
+	layers, err := loadBackgroundLayers(filepath.Join(assetsFilePath, backgroundsConfigFile))
+	if err != nil {
+		log.Println("Using the default background:", err)
+		layers = defaultBackgroundLayers
+	}
+	for i := range layers {
+		if layers[i].Tiling == "" {
+			layers[i].Tiling = TilingFitHeight
+		}
+		layers[i].TintColor, err = parseTint(layers[i].Tint)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	m.backgroundLayers = layers
+}
+
+func loadBackgroundLayers(path string) ([]BackgroundLayer, error) {
+	file, err := ebitenutil.OpenFile(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+	var config backgroundsConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+	if len(config.Layers) == 0 {
+		return nil, fmt.Errorf("%s has no layers", path)
+	}
+	return config.Layers, nil
+}
+
+// parseTint reads a CSS style hex color, which has straight alpha
+func parseTint(tint string) (color.NRGBA, error) {
+	if tint == "" {
+		return color.NRGBA{R: 255, G: 255, B: 255, A: 255}, nil
+	}
+	hex := strings.TrimPrefix(tint, "#")
+	if len(hex) == 6 {
+		hex += "ff"
+	}
+	value, err := strconv.ParseUint(hex, 16, 32)
+	if len(hex) != 8 || err != nil {
+		return color.NRGBA{}, fmt.Errorf("bad background tint %q", tint)
+	}
+	return color.NRGBA{R: uint8(value >> 24), G: uint8(value >> 16), B: uint8(value >> 8), A: uint8(value)}, nil
+}
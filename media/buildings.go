@@ -0,0 +1,52 @@
+package media
+
+import (
+	"image"
+	"log"
+	"path/filepath"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+const (
+	buildingSourceFileName        = "building4.png"         // the widest building has the most middle to tile
+	visitedBuildingSourceFileName = "visited-building4.png" // has to line up with the unvisited one pixel for pixel
+	BuildingEdgeWidth             = 25                      // the art is drawn in 25 pixel columns
+	BuildingTileWidth             = 25
+	BuildingFloorHeight           = 40 // rows at the bottom of the art that repeat to make a building taller
+)
+
+// BuildingPieces are the tileable parts buildings of any size are made from, they all have the height of the source art
+type BuildingPieces struct {
+	Left   *ebiten.Image
+	Middle *ebiten.Image // repeated as many times as it takes to fill the width
+	Right  *ebiten.Image
+}
+
+func (m *Manager) GetBuildingPieces(visited bool) BuildingPieces {
+	if visited {
+		return m.visitedBuildingPieces
+	}
+	return m.buildingPieces
+}
+
+func (m *Manager) initializeBuildingPieces() {
+	m.buildingPieces = loadBuildingPieces(buildingSourceFileName)
+	m.visitedBuildingPieces = loadBuildingPieces(visitedBuildingSourceFileName)
+}
+
+// loadBuildingPieces cuts a building into its left edge, a middle column and its right edge
+func loadBuildingPieces(fileName string) BuildingPieces {
+	source, _, err := ebitenutil.NewImageFromFile(filepath.Join(imagesFilePath, fileName))
+	if err != nil {
+		log.Fatal(err)
+	}
+	bounds := source.Bounds()
+	middleX := (bounds.Dx() - BuildingTileWidth) / 2
+	return BuildingPieces{
+		Left:   source.SubImage(image.Rect(0, 0, BuildingEdgeWidth, bounds.Dy())).(*ebiten.Image),
+		Middle: source.SubImage(image.Rect(middleX, 0, middleX+BuildingTileWidth, bounds.Dy())).(*ebiten.Image),
+		Right:  source.SubImage(image.Rect(bounds.Dx()-BuildingEdgeWidth, 0, bounds.Dx(), bounds.Dy())).(*ebiten.Image),
+	}
+}
@@ -0,0 +1,52 @@
+package media
+
+import (
+	"log"
+	"path/filepath"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+const defaultLanguage = "en" // the images in assets/images/ are in English
+
+// localizableImages are the images with words in them, translations go in assets/images/<language>/ with the same names
+func (m *Manager) localizableImages() map[string]**ebiten.Image {
+	return map[string]**ebiten.Image{
+		"play-button.png":        &m.playButtonImage,
+		"copy-score-prompt.png":  &m.copyScorePromptButtonImage,
+		"copy-score-success.png": &m.copyScoreSuccessButtonImage,
+		"text-enter-restart.png": &m.restartButtonImage,
+		"text-tap-restart.png":   &m.mobileRestartButtonImage,
+	}
+}
+
+// LocalizeImages swaps in the images translated to the language, keeping the English ones that haven't been translated
+func (m *Manager) LocalizeImages(language string) {
+	if m.englishImages == nil {
+		m.englishImages = map[string]*ebiten.Image{}
+		for fileName, image := range m.localizableImages() {
+			m.englishImages[fileName] = *image
+		}
+	}
+	m.language = language
+	m.translatedImages = map[*ebiten.Image]bool{}
+	for fileName, image := range m.localizableImages() {
+		*image = m.englishImages[fileName]
+		if language == defaultLanguage {
+			continue
+		}
+		translated, _, err := ebitenutil.NewImageFromFile(filepath.Join(imagesFilePath, language, fileName))
+		if err != nil {
+			log.Println("No", language, "translation for", fileName)
+			continue
+		}
+		*image = translated
+		m.translatedImages[translated] = true
+	}
+}
+
+// IsTranslated reports whether the image's words are in the current language
+func (m *Manager) IsTranslated(image *ebiten.Image) bool {
+	return m.language == "" || m.language == defaultLanguage || m.translatedImages[image]
+}
@@ -11,25 +11,21 @@ import (
 )
 
 const (
-	imagesFilePath               = "assets/images/"
-	runningImageFileName         = "guy"
-	idleImageFileName            = "idle"
-	backgroundImageFileName      = "layer"
-	buildingImageFileName        = "building"
-	visitedBuildingImageFileName = "visited-building"
-	imageFileExtension           = ".png"
-	NumPlayerImages              = 8
-	NumIdleImages                = 2
-	NumBackgroundLayers          = 1 // Warning: if this number is greater than the number of speeds in NewLayers, then it will panic
-	NumBuildingImages            = 5
+	imagesFilePath       = "assets/images/"
+	runningImageFileName = "guy"
+	idleImageFileName    = "idle"
+	imageFileExtension   = ".png"
+	NumPlayerImages      = 8
+	NumIdleImages        = 2
 )
 
 type Manager struct {
 	playerImages                map[string]*ebiten.Image
 	idleImages                  map[string]*ebiten.Image
+	backgroundLayers            []BackgroundLayer
 	backgroundImages            map[string]*ebiten.Image
-	buildingImages              map[string]*ebiten.Image
-	visitedBuildingImages       map[string]*ebiten.Image
+	buildingPieces              BuildingPieces
+	visitedBuildingPieces       BuildingPieces
 	cloudImage                  *ebiten.Image
 	titleImage                  *ebiten.Image
 	mutedImage                  *ebiten.Image
@@ -39,6 +35,10 @@ type Manager struct {
 	copyScoreSuccessButtonImage *ebiten.Image
 	restartButtonImage          *ebiten.Image
 	mobileRestartButtonImage    *ebiten.Image
+	language                    string
+	englishImages               map[string]*ebiten.Image
+	translatedImages            map[*ebiten.Image]bool
+	sounds                      map[Sound][][]byte
 }
 
 var (
@@ -54,9 +54,9 @@ func NewManager() *Manager {
 	result := &Manager{}
 	result.initializeRunningImages()
 	result.initializeIdleImages()
+	result.initializeBackgroundLayers()
 	result.initializeBackgroundImages()
-	result.initializeBuildingImages()
-	result.initializeVisitedBuildingImages()
+	result.initializeBuildingPieces()
 	result.initializeCloudImage()
 	result.initializeTitleImage()
 	result.initializeMutedImage()
@@ -66,6 +66,7 @@ func NewManager() *Manager {
 	result.initializeCopyScoreSuccessButtonImage()
 	result.initializeRestartButtonImage()
 	result.initializeMobileRestartButtonImage()
+	result.initializeSounds()
 	return result
 }
 
@@ -87,8 +88,7 @@ func (m *Manager) LoadIdleImage(i int) (*ebiten.Image, error) {
 	return image, nil
 }
 
-func (m *Manager) LoadBackgroundImage(i int) (*ebiten.Image, error) {
-	fileName := buildBackgroundImageFileName(i)
+func (m *Manager) LoadBackgroundImage(fileName string) (*ebiten.Image, error) {
 	image, ok := m.backgroundImages[fileName]
 	if !ok {
 		return nil, errImageNotFound
@@ -96,24 +96,6 @@ func (m *Manager) LoadBackgroundImage(i int) (*ebiten.Image, error) {
 	return image, nil
 }
 
-func (m *Manager) LoadBuildingImage(i int) (*ebiten.Image, error) {
-	fileName := buildBuildingImageFileName(i)
-	image, ok := m.buildingImages[fileName]
-	if !ok {
-		return nil, errImageNotFound
-	}
-	return image, nil
-}
-
-func (m *Manager) LoadVisitedBuildingImage(i int) (*ebiten.Image, error) {
-	fileName := buildVisitedBuildingImageFileName(i)
-	image, ok := m.visitedBuildingImages[fileName]
-	if !ok {
-		return nil, errImageNotFound
-	}
-	return image, nil
-}
-
 func (m *Manager) GetCloudImage() *ebiten.Image {
 	return m.cloudImage
 }
@@ -178,37 +160,16 @@ func (m *Manager) initializeIdleImages() {
 
 func (m *Manager) initializeBackgroundImages() {
 	m.backgroundImages = make(map[string]*ebiten.Image)
-	for i := range NumBackgroundLayers {
-		fileName := buildBackgroundImageFileName(i)
-		image, _, err := ebitenutil.NewImageFromFile(filepath.Join(imagesFilePath, fileName))
-		if err != nil {
-			log.Fatal(err)
+	for _, layer := range m.backgroundLayers {
+		fileName := layer.Image
+		if _, ok := m.backgroundImages[fileName]; ok {
+			continue
 		}
-		m.backgroundImages[fileName] = image
-	}
-}
-
-func (m *Manager) initializeBuildingImages() {
-	m.buildingImages = make(map[string]*ebiten.Image)
-	for i := range NumBuildingImages {
-		fileName := buildBuildingImageFileName(i)
-		image, _, err := ebitenutil.NewImageFromFile(filepath.Join(imagesFilePath, fileName))
-		if err != nil {
-			log.Fatal(err)
-		}
-		m.buildingImages[fileName] = image
-	}
-}
-
-func (m *Manager) initializeVisitedBuildingImages() {
-	m.visitedBuildingImages = make(map[string]*ebiten.Image)
-	for i := range NumBuildingImages {
-		fileName := buildVisitedBuildingImageFileName(i)
 		image, _, err := ebitenutil.NewImageFromFile(filepath.Join(imagesFilePath, fileName))
 		if err != nil {
 			log.Fatal(err)
 		}
-		m.visitedBuildingImages[fileName] = image
+		m.backgroundImages[fileName] = image
 	}
 }
 
@@ -302,15 +263,3 @@ func buildRunningImageFileName(i int) string {
 func buildIdleImageFileName(i int) string {
 	return fmt.Sprintf("%s%d%s", idleImageFileName, i, imageFileExtension)
 }
-
-func buildBackgroundImageFileName(i int) string {
-	return fmt.Sprintf("%s%d%s", backgroundImageFileName, i, imageFileExtension)
-}
-
-func buildBuildingImageFileName(i int) string {
-	return fmt.Sprintf("%s%d%s", buildingImageFileName, i, imageFileExtension)
-}
-
-func buildVisitedBuildingImageFileName(i int) string {
-	return fmt.Sprintf("%s%d%s", visitedBuildingImageFileName, i, imageFileExtension)
-}
@@ -0,0 +1,63 @@
+package media
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"path/filepath"
+
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+const soundsFilePath = "assets/sounds/"
+
+// Sound is a game event with a sound effect, its files are <sound>-1.wav, <sound>-2.wav and so on
+type Sound string
+
+const (
+	SoundJump      Sound = "jump"
+	SoundLand      Sound = "land"
+	SoundGeocode   Sound = "geocode"
+	SoundFall      Sound = "fall"
+	SoundClick     Sound = "click"
+	SoundMilestone Sound = "milestone"
+)
+
+// soundVariants is how many recordings there are of each sound, the game picks one at random every time
+var soundVariants = map[Sound]int{
+	SoundJump:      2,
+	SoundLand:      3,
+	SoundGeocode:   1,
+	SoundFall:      1,
+	SoundClick:     1,
+	SoundMilestone: 1,
+}
+
+// GetSoundVariants is every recording of the sound as WAV data, it's empty if none of them could be loaded
+func (m *Manager) GetSoundVariants(sound Sound) [][]byte {
+	return m.sounds[sound]
+}
+
+// initializeSounds only logs sounds that are missing, the game is still playable without them
+func (m *Manager) initializeSounds() {
+	m.sounds = make(map[Sound][][]byte, len(soundVariants))
+	for sound, variants := range soundVariants {
+		for i := range variants {
+			data, err := readFile(filepath.Join(soundsFilePath, fmt.Sprintf("%s-%d.wav", sound, i+1)))
+			if err != nil {
+				log.Println("Skipping a sound effect:", err)
+				continue
+			}
+			m.sounds[sound] = append(m.sounds[sound], data)
+		}
+	}
+}
+
+func readFile(path string) ([]byte, error) {
+	file, err := ebitenutil.OpenFile(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return io.ReadAll(file)
+}
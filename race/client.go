@@ -0,0 +1,108 @@
+//go:build js && wasm
+// +build js,wasm
+
+package race
+
+import (
+	"encoding/json"
+	"sync"
+	"syscall/js"
+)
+
+// Client talks to the race server through the browser's WebSocket
+type Client struct {
+	mu       sync.Mutex
+	socket   js.Value
+	open     bool
+	closed   bool
+	pending  []Message
+	incoming []Message
+	funcs    []js.Func
+}
+
+func Dial(url string) (*Client, error) {
+	c := &Client{socket: js.Global().Get("WebSocket").New(url)}
+	c.on("open", func(js.Value) {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.open = true
+		for _, msg := range c.pending {
+			c.write(msg)
+		}
+		c.pending = nil
+	})
+	c.on("message", func(event js.Value) {
+		var msg Message
+		if err := json.Unmarshal([]byte(event.Get("data").String()), &msg); err != nil {
+			println("Bad race message:", err.Error())
+			return
+		}
+		c.mu.Lock()
+		c.incoming = append(c.incoming, msg)
+		c.mu.Unlock()
+	})
+	c.on("close", func(js.Value) {
+		c.mu.Lock()
+		c.closed = true
+		c.mu.Unlock()
+	})
+	return c, nil
+}
+
+func (c *Client) on(event string, fn func(js.Value)) {
+	callback := js.FuncOf(func(this js.Value, args []js.Value) any {
+		fn(args[0])
+		return nil
+	})
+	c.funcs = append(c.funcs, callback)
+	c.socket.Call("addEventListener", event, callback)
+}
+
+// Send queues the message until the socket is open
+func (c *Client) Send(msg Message) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return
+	}
+	if !c.open {
+		c.pending = append(c.pending, msg)
+		return
+	}
+	c.write(msg)
+}
+
+func (c *Client) write(msg Message) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		println("Could not send race message:", err.Error())
+		return
+	}
+	c.socket.Call("send", string(data))
+}
+
+// Poll returns every message received since the last call without blocking
+func (c *Client) Poll() []Message {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	messages := c.incoming
+	c.incoming = nil
+	return messages
+}
+
+func (c *Client) Closed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closed
+}
+
+func (c *Client) Close() {
+	c.mu.Lock()
+	c.closed = true
+	c.mu.Unlock()
+	c.socket.Call("close")
+	for _, callback := range c.funcs {
+		callback.Release()
+	}
+	c.funcs = nil
+}
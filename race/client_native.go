@@ -0,0 +1,83 @@
+//go:build !js || !wasm
+// +build !js !wasm
+
+package race
+
+import (
+	"log"
+	"net/url"
+	"sync"
+
+	"golang.org/x/net/websocket"
+)
+
+// Client talks to the race server over a websocket connection
+type Client struct {
+	mu       sync.Mutex
+	conn     *websocket.Conn
+	closed   bool
+	incoming []Message
+}
+
+func Dial(rawURL string) (*Client, error) {
+	serverURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	origin := &url.URL{Scheme: "http", Host: serverURL.Host}
+	conn, err := websocket.Dial(rawURL, "", origin.String())
+	if err != nil {
+		return nil, err
+	}
+	c := &Client{conn: conn}
+	go c.read()
+	return c, nil
+}
+
+func (c *Client) read() {
+	for {
+		var msg Message
+		if err := websocket.JSON.Receive(c.conn, &msg); err != nil {
+			c.mu.Lock()
+			c.closed = true
+			c.mu.Unlock()
+			return
+		}
+		c.mu.Lock()
+		c.incoming = append(c.incoming, msg)
+		c.mu.Unlock()
+	}
+}
+
+func (c *Client) Send(msg Message) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return
+	}
+	if err := websocket.JSON.Send(c.conn, msg); err != nil {
+		log.Println("Could not send race message:", err)
+	}
+}
+
+// Poll returns every message received since the last call without blocking
+func (c *Client) Poll() []Message {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	messages := c.incoming
+	c.incoming = nil
+	return messages
+}
+
+func (c *Client) Closed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closed
+}
+
+func (c *Client) Close() {
+	c.mu.Lock()
+	c.closed = true
+	c.mu.Unlock()
+	_ = c.conn.Close()
+}
@@ -0,0 +1,58 @@
+package race
+
+const (
+	MinPlayers = 2
+	MaxPlayers = 8
+)
+
+// Message types sent between the game and the server
+const (
+	TypeJoin    = "join"    // game -> server: join a room
+	TypeWelcome = "welcome" // server -> game: your id in the room
+	TypeLobby   = "lobby"   // server -> game: who is in the room
+	TypeStart   = "start"   // game -> server: the host starts the race, server -> game: the seed to play
+	TypeState   = "state"   // game -> server -> other games: where a runner is
+	TypeFell    = "fell"    // game -> server -> other games: a runner fell off the city
+	TypeResults = "results" // server -> game: the last runner fell, here is how everyone did
+	TypeLeft    = "left"    // server -> game: a runner disconnected
+	TypeError   = "error"   // server -> game: the request could not be done
+
+	TypeRun         = "run"         // booth game -> server -> spectators: a new run started with this seed
+	TypeWatch       = "watch"       // spectator -> server: follow the runs in a booth
+	TypeLeaderboard = "leaderboard" // server -> spectators: the best scores in the booth
+)
+
+const LeaderboardSize = 10
+
+type Message struct {
+	Type    string       `json:"type"`
+	Room    string       `json:"room,omitempty"`
+	Name    string       `json:"name,omitempty"`
+	ID      int          `json:"id,omitempty"`
+	HostID  int          `json:"hostId,omitempty"`
+	Seed    int64        `json:"seed,omitempty"`
+	Score   int          `json:"score,omitempty"`
+	State   *RunnerState `json:"state,omitempty"`
+	Players []PlayerInfo `json:"players,omitempty"`
+	Results []Result     `json:"results,omitempty"`
+	Error   string       `json:"error,omitempty"`
+}
+
+type RunnerState struct {
+	X         float64 `json:"x"`
+	Y         float64 `json:"y"`
+	VelocityX float64 `json:"vx"`
+	Jumping   bool    `json:"jumping"`
+	Score     int     `json:"score"`
+}
+
+type PlayerInfo struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type Result struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Score int    `json:"score"`
+}